@@ -1,11 +1,20 @@
 package ubit
 
+import (
+	"time"
+
+	"github.com/wencode/ubit/hal"
+)
+
 type ModAudio struct {
 	is_playing bool
+	speaker    hal.Speaker
 }
 
 func NewModAudio() *ModAudio {
-	return &ModAudio{}
+	return &ModAudio{
+		speaker: newSpeaker(),
+	}
 }
 
 func (m *ModAudio) Play(source string) error {
@@ -14,4 +23,20 @@ func (m *ModAudio) Play(source string) error {
 
 func (m *ModAudio) IsPlaying() bool { return m.is_playing }
 
-func (m *ModAudio) Stop() {}
+// Stop silences the speaker.
+func (m *ModAudio) Stop() {
+	m.speaker.Tone(0)
+	m.is_playing = false
+}
+
+// Pitch plays frequency Hz on the speaker for ms milliseconds, a negative
+// ms plays it until Stop.
+func (m *ModAudio) Pitch(frequency int, ms int) {
+	m.is_playing = true
+	m.speaker.Tone(uint32(frequency))
+	if ms < 0 {
+		return
+	}
+	time.Sleep(time.Duration(ms) * time.Millisecond)
+	m.Stop()
+}
@@ -0,0 +1,49 @@
+//go:build !tinygo
+// +build !tinygo
+
+package ubit
+
+import (
+	"os"
+	"sync"
+
+	"github.com/wencode/ubit/hal"
+	"github.com/wencode/ubit/sim"
+)
+
+// On the host the display drives a board simulated in the terminal.
+var simBoard = sim.NewBoard(os.Stdout, os.Stdin)
+
+// displayLock serialises the program, bgloop and the simulated scan, which
+// run in parallel on the host.
+type displayLock struct {
+	mu sync.Mutex
+}
+
+func (l *displayLock) lock() { l.mu.Lock() }
+
+func (l *displayLock) unlock() { l.mu.Unlock() }
+
+func newLedPins() (rows, cols [5]hal.Pin) {
+	for i := 0; i < 5; i++ {
+		rows[i] = simBoard.Rows[i]
+		cols[i] = simBoard.Cols[i]
+	}
+	return
+}
+
+func newScanTimer() hal.Timer {
+	return simBoard.Timer()
+}
+
+func newSenseADC(col int) hal.ADC {
+	return simBoard.SenseADC(col)
+}
+
+func newButtonPins() (a, b hal.Pin) {
+	return simBoard.ButtonA, simBoard.ButtonB
+}
+
+func newSpeaker() hal.Speaker {
+	return simBoard.Speaker()
+}
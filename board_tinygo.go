@@ -0,0 +1,101 @@
+//go:build tinygo
+// +build tinygo
+
+package ubit
+
+import (
+	"machine"
+
+	"github.com/wencode/ubit/hal"
+	"github.com/wencode/ubit/nrf/pwm"
+	"github.com/wencode/ubit/nrf/timer"
+)
+
+// displayLock does nothing on the board: bgloop and the program only switch
+// where they block, and the scan interrupt reads the frame buffer once no
+// batch is open, see display_buffer.go.
+type displayLock struct{}
+
+func (displayLock) lock() {}
+
+func (displayLock) unlock() {}
+
+func newLedPins() (rows, cols [5]hal.Pin) {
+	rows = [5]hal.Pin{
+		hal.MachinePin(machine.LED_ROW_1),
+		hal.MachinePin(machine.LED_ROW_2),
+		hal.MachinePin(machine.LED_ROW_3),
+		hal.MachinePin(machine.LED_ROW_4),
+		hal.MachinePin(machine.LED_ROW_5),
+	}
+	cols = [5]hal.Pin{
+		hal.MachinePin(machine.LED_COL_1),
+		hal.MachinePin(machine.LED_COL_2),
+		hal.MachinePin(machine.LED_COL_3),
+		hal.MachinePin(machine.LED_COL_4),
+		hal.MachinePin(machine.LED_COL_5),
+	}
+	return
+}
+
+func newScanTimer() hal.Timer {
+	t := timer.Get(timer.ID1)
+	t.SetPriority(display_irq_priority)
+	return t
+}
+
+// newSenseADC returns the ADC on the pin of matrix column col, which must
+// be one of senseColumns.
+func newSenseADC(col int) hal.ADC {
+	pins := [5]machine.Pin{
+		machine.LED_COL_1,
+		machine.LED_COL_2,
+		machine.LED_COL_3,
+		machine.LED_COL_4,
+		machine.LED_COL_5,
+	}
+	return hal.NewMachineADC(pins[col])
+}
+
+func newButtonPins() (a, b hal.Pin) {
+	return hal.MachinePin(machine.BUTTONA), hal.MachinePin(machine.BUTTONB)
+}
+
+func newSpeaker() hal.Speaker {
+	return &pwmSpeaker{}
+}
+
+// pwmSpeaker plays tones on the speaker with PWM0, counting at 1MHz.
+type pwmSpeaker struct {
+	p    *pwm.PWM
+	duty [1]uint16
+}
+
+func (s *pwmSpeaker) Tone(frequency uint32) {
+	if s.p != nil {
+		s.p.Stop(true)
+		s.p.Uninit()
+		s.p = nil
+	}
+	if frequency == 0 {
+		return
+	}
+	// COUNTERTOP is 15 bits wide
+	top := 1000000 / frequency
+	if top > 0x7FFF {
+		top = 0x7FFF
+	}
+	p, err := pwm.Init(pwm.ID0,
+		pwm.WithBaseCLK(pwm.CLK_1MHz),
+		pwm.WithTopValue(uint16(top)),
+		pwm.WithOutputPin(machine.SPEAKER_PIN),
+	)
+	if err != nil {
+		return
+	}
+	s.duty[0] = uint16(top / 2)
+	seq := pwm.NewSequence(s.duty[:])
+	// loops until stopped
+	p.Playback(seq, seq, 2)
+	s.p = p
+}
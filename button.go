@@ -0,0 +1,25 @@
+package ubit
+
+import (
+	"github.com/wencode/ubit/hal"
+)
+
+// ModButton is one of the two buttons on the front of the board.
+type ModButton struct {
+	pin hal.Pin
+}
+
+func NewModButton(pin hal.Pin) *ModButton {
+	return &ModButton{pin: pin}
+}
+
+// Init configures the pin of the button as an input.
+func (b *ModButton) Init() {
+	b.pin.Configure(hal.PinInput)
+}
+
+// IsPressed reports whether the button is held down, the button pulls its
+// pin low.
+func (b *ModButton) IsPressed() bool {
+	return !b.pin.Get()
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parseBDF reads a font in the Glyph Bitmap Distribution Format. Glyphs
+// are placed in cells of the size of FONTBOUNDINGBOX, and take DWIDTH-1
+// columns in text when they are blank.
+func parseBDF(data []byte) (*font, error) {
+	var (
+		f                  *font
+		g                  *glyph
+		enc                = -1
+		dwidth             int
+		bbw, bbh, bbx, bby int
+		fbx, fby           int
+		row                = -1
+	)
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; sc.Scan(); line++ {
+		fields := strings.Fields(sc.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		ints := func(n int) ([]int, error) {
+			if len(fields) < n+1 {
+				return nil, fmt.Errorf("line %d: %s needs %d values", line, fields[0], n)
+			}
+			v := make([]int, n)
+			for i := range v {
+				var err error
+				if v[i], err = strconv.Atoi(fields[i+1]); err != nil {
+					return nil, fmt.Errorf("line %d: %v", line, err)
+				}
+			}
+			return v, nil
+		}
+
+		if row >= 0 {
+			if fields[0] == "ENDCHAR" {
+				row = -1
+				continue
+			}
+			bits, err := strconv.ParseUint(fields[0], 16, 32)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: bad bitmap row, or wider than 32 pixels", line)
+			}
+			nbits := 4 * len(fields[0])
+			if g != nil {
+				// the glyph box sits on the baseline like the font box
+				y := fby + f.height - bby - bbh + row
+				for x := 0; x < bbw && x < nbits; x++ {
+					if bits>>uint(nbits-1-x)&1 != 0 {
+						f.set(g, bbx-fbx+x, y)
+					}
+				}
+			}
+			row++
+			continue
+		}
+
+		switch fields[0] {
+		case "FONTBOUNDINGBOX":
+			v, err := ints(4)
+			if err != nil {
+				return nil, err
+			}
+			f = newFont(v[0], v[1])
+			fbx, fby = v[2], v[3]
+		case "STARTCHAR":
+			enc, dwidth = -1, 0
+		case "ENCODING":
+			v, err := ints(1)
+			if err != nil {
+				return nil, err
+			}
+			enc = v[0]
+		case "DWIDTH":
+			v, err := ints(1)
+			if err != nil {
+				return nil, err
+			}
+			dwidth = v[0]
+		case "BBX":
+			v, err := ints(4)
+			if err != nil {
+				return nil, err
+			}
+			bbw, bbh, bbx, bby = v[0], v[1], v[2], v[3]
+		case "BITMAP":
+			if f == nil {
+				return nil, fmt.Errorf("line %d: BITMAP before FONTBOUNDINGBOX", line)
+			}
+			g = nil
+			if enc >= 0 {
+				g = f.newGlyph(rune(enc))
+				if dwidth > 1 {
+					g.blank = dwidth - 1
+				}
+			}
+			row = 0
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	if f == nil {
+		return nil, errors.New("not a BDF font")
+	}
+	return f, nil
+}
@@ -0,0 +1,52 @@
+package main
+
+// font is a bitmap font as read from any format, its glyphs are drawn in
+// cells of width*height pixels.
+type font struct {
+	width  int
+	height int
+	glyphs map[rune]*glyph
+}
+
+type glyph struct {
+	pix []bool
+	// columns taken in text by a blank glyph, 0 for half the cell
+	blank int
+}
+
+func newFont(width, height int) *font {
+	return &font{
+		width:  width,
+		height: height,
+		glyphs: make(map[rune]*glyph),
+	}
+}
+
+func (f *font) newGlyph(r rune) *glyph {
+	g := &glyph{pix: make([]bool, f.width*f.height)}
+	f.glyphs[r] = g
+	return g
+}
+
+func (f *font) set(g *glyph, x, y int) {
+	if x >= 0 && x < f.width && y >= 0 && y < f.height {
+		g.pix[y*f.width+x] = true
+	}
+}
+
+// litColumns returns the first and the last lit column of g, first is
+// greater than last if g is blank.
+func (f *font) litColumns(g *glyph) (first, last int) {
+	first, last = f.width, -1
+	for i, lit := range g.pix {
+		if x := i % f.width; lit {
+			if x < first {
+				first = x
+			}
+			if x > last {
+				last = x
+			}
+		}
+	}
+	return first, last
+}
@@ -0,0 +1,230 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"go/parser"
+	"go/token"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/wencode/ubit/font5x5"
+)
+
+// draw returns the rows of g as strings of # and .
+func draw(f *font, g *glyph) []string {
+	var rows []string
+	for y := 0; y < f.height; y++ {
+		s := ""
+		for x := 0; x < f.width; x++ {
+			if g.pix[y*f.width+x] {
+				s += "#"
+			} else {
+				s += "."
+			}
+		}
+		rows = append(rows, s)
+	}
+	return rows
+}
+
+func checkGlyph(t *testing.T, f *font, r rune, want ...string) {
+	g := f.glyphs[r]
+	if g == nil {
+		t.Errorf("no glyph for %q", r)
+		return
+	}
+	if got := draw(f, g); strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("glyph for %q = %v, want %v", r, got, want)
+	}
+}
+
+const testBDF = `STARTFONT 2.1
+FONT -test-3x5
+FONTBOUNDINGBOX 3 5 0 -1
+CHARS 3
+STARTCHAR space
+ENCODING 32
+DWIDTH 3 0
+BBX 1 1 0 0
+BITMAP
+00
+ENDCHAR
+STARTCHAR A
+ENCODING 65
+DWIDTH 4 0
+BBX 3 4 0 0
+BITMAP
+40
+A0
+E0
+A0
+ENDCHAR
+STARTCHAR comma
+ENCODING 44
+DWIDTH 2 0
+BBX 1 2 0 -1
+BITMAP
+80
+80
+ENDCHAR
+ENDFONT
+`
+
+func TestBDF(t *testing.T) {
+	f, err := parseBDF([]byte(testBDF))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.width != 3 || f.height != 5 {
+		t.Fatalf("cell %dx%d", f.width, f.height)
+	}
+	checkGlyph(t, f, 'A', ".#.", "#.#", "###", "#.#", "...")
+	checkGlyph(t, f, ',', "...", "...", "...", "#..", "#..")
+
+	// a blank space takes DWIDTH-1 columns, missing glyphs half the cell
+	_, widths := pack(f, ' ', 'A')
+	if widths[0] != 2 || widths[','-' '] != 1 || widths['!'-' '] != 2 || widths['A'-' '] != 3 {
+		t.Errorf("widths %v", widths)
+	}
+}
+
+func TestPSF1(t *testing.T) {
+	data := []byte{0x36, 0x04, 0, 5}
+	glyphs := make([]byte, 256*5)
+	copy(glyphs['T'*5:], []byte{0xe0, 0x40, 0x40, 0x40, 0x40})
+	f, err := parsePSF(append(data, glyphs...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkGlyph(t, f, 'T', "###.....", ".#......", ".#......", ".#......", ".#......")
+}
+
+func TestPSF2(t *testing.T) {
+	header := make([]byte, 32)
+	le := binary.LittleEndian
+	le.PutUint32(header[0:], psf2_magic)
+	le.PutUint32(header[8:], 32)
+	le.PutUint32(header[12:], psf2_hasTable)
+	le.PutUint32(header[16:], 2)
+	le.PutUint32(header[20:], 5)
+	le.PutUint32(header[24:], 5)
+	le.PutUint32(header[28:], 4)
+	data := append(header,
+		0x90, 0x60, 0x60, 0x90, 0x00,
+		0x70, 0x80, 0xe0, 0x80, 0x70,
+	)
+	// glyph 0 is x, glyph 1 the euro sign and a sequence to skip
+	data = append(data, 'x', psf2_end)
+	data = append(data, []byte("€")...)
+	data = append(data, psf2_separator, 'E', '=', psf2_end)
+
+	f, err := parsePSF(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkGlyph(t, f, 'x', "#..#", ".##.", ".##.", "#..#", "....")
+	checkGlyph(t, f, '€', ".###", "#...", "###.", "#...", ".###")
+	if len(f.glyphs) != 2 {
+		t.Errorf("%d glyphs, want 2", len(f.glyphs))
+	}
+}
+
+// TestSheet reads a sheet of the digits of the default font and checks the
+// packed glyphs against it.
+func TestSheet(t *testing.T) {
+	sheet := image.NewGray(image.Rect(0, 0, 5*5, 2*5))
+	for d := 0; d < 10; d++ {
+		img := font5x5.GenImage5x5(rune('0'+d), 255)
+		for y := 0; y < 5; y++ {
+			for x := 0; x < 5; x++ {
+				sheet.SetGray(d%5*5+x, d/5*5+y, color.Gray{Y: img[y*5+x]})
+			}
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, sheet); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parseSheet(buf.Bytes(), 5, 5, '0')
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, widths := pack(f, '0', '9')
+	tf := &font5x5.TableFont{First: '0', Last: '9', Width: 5, Height: 5, Data: data}
+	for r := '0'; r <= '9'; r++ {
+		got, _ := tf.Glyph(r)
+		if want := font5x5.GenImage5x5(r, 255); got != want {
+			t.Errorf("glyph for %q = %v, want %v", r, got, want)
+		}
+		if _, want := font5x5.GlyphSpan(r); int(widths[r-'0']) != want {
+			t.Errorf("width of %q = %d, want %d", r, widths[r-'0'], want)
+		}
+	}
+
+	src, err := generate(f, genConfig{pkg: "fonts", name: "Digits", source: "digits.png", first: '0', last: '9', widths: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), "digits.go", src, 0); err != nil {
+		t.Errorf("generated source: %v\n%s", err, src)
+	}
+	if !bytes.Contains(src, []byte("var Digits = &font5x5.TableFont{")) {
+		t.Errorf("generated source:\n%s", src)
+	}
+}
+
+func TestParseRune(t *testing.T) {
+	cases := []struct {
+		s    string
+		want rune
+	}{
+		{"32", ' '},
+		{"0x7e", '~'},
+		{"A", 'A'},
+		{"€", '€'},
+	}
+	for _, c := range cases {
+		if got, err := parseRune(c.s); err != nil || got != c.want {
+			t.Errorf("parseRune(%q) = %q, %v", c.s, got, err)
+		}
+	}
+	if _, err := parseRune("AB"); err == nil {
+		t.Error("parsed AB")
+	}
+}
+
+func TestLimits(t *testing.T) {
+	f := newFont(7, 6)
+	f.set(f.newGlyph('a'), 4, 4)
+	f.set(f.newGlyph('b'), 5, 0)
+	f.set(f.newGlyph('c'), 0, 5)
+	if rs := cropped(f, 'a', 'c'); string(rs) != "bc" {
+		t.Errorf("cropped %q", string(rs))
+	}
+	if _, err := generate(f, genConfig{pkg: "main", name: "F", first: 'a', last: 'c'}); err != nil {
+		t.Error(err)
+	}
+
+	// the widest font still reads back through TableFont
+	f = newFont(gen_maxWidth, 5)
+	f.set(f.newGlyph('a'), 0, 0)
+	data, _ := pack(f, 'a', 'a')
+	tf := &font5x5.TableFont{First: 'a', Last: 'a', Width: f.width, Height: f.height, Data: data}
+	if img, _ := tf.Glyph('a'); img[0] == 0 {
+		t.Errorf("%d pixels wide glyph lost its left column", f.width)
+	}
+
+	wide := newFont(gen_maxWidth+1, 5)
+	if _, err := generate(wide, genConfig{pkg: "main", name: "F", first: 'a', last: 'a'}); err == nil {
+		t.Errorf("%d pixels wide font generated", wide.width)
+	}
+	bdf := strings.Replace(testBDF, "BBX 3 4 0 0\nBITMAP\n40", "BBX 3 4 0 0\nBITMAP\n4000000000", 1)
+	if _, err := parseBDF([]byte(bdf)); err == nil {
+		t.Error("40 pixels wide bitmap row parsed")
+	}
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+)
+
+const (
+	// widest cell font5x5.TableFont can read, it decodes rows into a uint32
+	gen_maxWidth = 31
+	// size shown on the display, glyphs are cropped to their top left 5x5
+	gen_shownWidth  = 5
+	gen_shownHeight = 5
+)
+
+type genConfig struct {
+	pkg    string
+	name   string
+	source string
+	first  rune
+	last   rune
+	widths bool
+}
+
+// pack returns the glyphs of f from first to last in the layout of
+// font5x5.GetFontData: rows take 1+width/8 bytes, bit width-1 of a row
+// being its left column. Characters without a glyph are left blank. It
+// also returns the number of columns each glyph takes in text.
+func pack(f *font, first, last rune) (data []byte, widths []uint8) {
+	stride := 1 + f.width/8
+	for r := first; r <= last; r++ {
+		g := f.glyphs[r]
+		if g == nil {
+			g = &glyph{pix: make([]bool, f.width*f.height)}
+		}
+		for y := 0; y < f.height; y++ {
+			var row uint32
+			for x := 0; x < f.width; x++ {
+				if g.pix[y*f.width+x] {
+					row |= 1 << uint(f.width-1-x)
+				}
+			}
+			for i := stride - 1; i >= 0; i-- {
+				data = append(data, byte(row>>uint(8*i)))
+			}
+		}
+
+		lo, hi := f.litColumns(g)
+		switch {
+		case lo <= hi:
+			widths = append(widths, uint8(hi-lo+1))
+		case g.blank > 0:
+			widths = append(widths, uint8(g.blank))
+		default:
+			widths = append(widths, uint8((f.width+1)/2))
+		}
+	}
+	return data, widths
+}
+
+// cropped returns the characters from first to last with lit pixels
+// outside the top left 5x5 of their cell, which the display does not show.
+func cropped(f *font, first, last rune) []rune {
+	var rs []rune
+	for r := first; r <= last; r++ {
+		g := f.glyphs[r]
+		if g == nil {
+			continue
+		}
+		for i, lit := range g.pix {
+			if lit && (i%f.width >= gen_shownWidth || i/f.width >= gen_shownHeight) {
+				rs = append(rs, r)
+				break
+			}
+		}
+	}
+	return rs
+}
+
+// generate returns the Go source of a font5x5.TableFont holding the glyphs
+// of f from cfg.first to cfg.last.
+func generate(f *font, cfg genConfig) ([]byte, error) {
+	if f.width > gen_maxWidth {
+		return nil, fmt.Errorf("%d pixels wide, at most %d are supported", f.width, gen_maxWidth)
+	}
+	data, widths := pack(f, cfg.first, cfg.last)
+	size := (1 + f.width/8) * f.height
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by fontgen from %s. DO NOT EDIT.\n\n", cfg.source)
+	fmt.Fprintf(&buf, "package %s\n\n", cfg.pkg)
+	fmt.Fprintf(&buf, "import \"github.com/wencode/ubit/font5x5\"\n\n")
+	fmt.Fprintf(&buf, "// %s holds the glyphs of %s from %U to %U.\n", cfg.name, cfg.source, cfg.first, cfg.last)
+	fmt.Fprintf(&buf, "var %s = &font5x5.TableFont{\n", cfg.name)
+	fmt.Fprintf(&buf, "First: %#x,\nLast: %#x,\nWidth: %d,\nHeight: %d,\n", cfg.first, cfg.last, f.width, f.height)
+	buf.WriteString("Data: []byte{\n")
+	for i := 0; i < len(data); i += size {
+		for _, b := range data[i : i+size] {
+			fmt.Fprintf(&buf, "%#x, ", b)
+		}
+		fmt.Fprintf(&buf, "// %q\n", cfg.first+rune(i/size))
+	}
+	buf.WriteString("},\n")
+	if cfg.widths {
+		buf.WriteString("Widths: []uint8{\n")
+		for i, w := range widths {
+			fmt.Fprintf(&buf, "%d,", w)
+			if i%16 == 15 || i == len(widths)-1 {
+				buf.WriteString("\n")
+			} else {
+				buf.WriteString(" ")
+			}
+		}
+		buf.WriteString("},\n")
+	}
+	buf.WriteString("}\n")
+	return format.Source(buf.Bytes())
+}
@@ -0,0 +1,124 @@
+// Command fontgen converts a bitmap font into a font5x5.TableFont, so that
+// other small fonts can be tried without packing glyphs by hand.
+//
+//	fontgen -first 32 -last 126 -var Tom3x5 tom-thumb.bdf > tom.go
+//
+// It reads BDF and PSF fonts, and PNG sheets of glyphs laid out left to
+// right and top to bottom in cells of -cell pixels, the first cell being
+// the glyph for -first. A pixel of a sheet is lit when it is more light
+// than dark and more opaque than not.
+//
+// Cells can be at most 31 pixels wide. Only the top left 5x5 pixels of a
+// glyph are shown, fontgen warns about glyphs that are cropped.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+func main() {
+	var (
+		first  = flag.String("first", "32", "first character, as a number or the character itself")
+		last   = flag.String("last", "126", "last character, as a number or the character itself")
+		format = flag.String("format", "", "bdf, psf or png, by default from the file name")
+		cell   = flag.String("cell", "5x5", "size of the cells of a png sheet")
+		pkg    = flag.String("pkg", "main", "package of the generated file")
+		name   = flag.String("var", "Font", "name of the generated variable")
+		widths = flag.Bool("widths", true, "record the width of every glyph")
+		out    = flag.String("o", "", "output file, standard output by default")
+	)
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: fontgen [flags] font\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := run(flag.Arg(0), *first, *last, *format, *cell, *pkg, *name, *widths, *out); err != nil {
+		fmt.Fprintf(os.Stderr, "fontgen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(path, first, last, format, cell, pkg, name string, widths bool, out string) error {
+	lo, err := parseRune(first)
+	if err != nil {
+		return err
+	}
+	hi, err := parseRune(last)
+	if err != nil {
+		return err
+	}
+	if hi < lo {
+		return fmt.Errorf("last %q before first %q", hi, lo)
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	}
+	var f *font
+	switch format {
+	case "bdf":
+		f, err = parseBDF(data)
+	case "psf", "psfu":
+		f, err = parsePSF(data)
+	case "png":
+		var w, h int
+		if _, err := fmt.Sscanf(cell, "%dx%d", &w, &h); err != nil || w <= 0 || h <= 0 {
+			return fmt.Errorf("bad cell size %q", cell)
+		}
+		f, err = parseSheet(data, w, h, lo)
+	default:
+		return fmt.Errorf("unknown format %q", format)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %v", path, err)
+	}
+
+	if rs := cropped(f, lo, hi); len(rs) > 0 {
+		fmt.Fprintf(os.Stderr, "fontgen: warning: %d glyphs larger than %dx%d are cropped, the first is %q\n",
+			len(rs), gen_shownWidth, gen_shownHeight, rs[0])
+	}
+	src, err := generate(f, genConfig{
+		pkg:    pkg,
+		name:   name,
+		source: filepath.Base(path),
+		first:  lo,
+		last:   hi,
+		widths: widths,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %v", path, err)
+	}
+	if out == "" {
+		_, err = os.Stdout.Write(src)
+		return err
+	}
+	return ioutil.WriteFile(out, src, 0644)
+}
+
+// parseRune reads a character given as a decimal or 0x prefixed number,
+// or as the character itself.
+func parseRune(s string) (rune, error) {
+	if n, err := strconv.ParseInt(s, 0, 32); err == nil {
+		return rune(n), nil
+	}
+	if r, size := utf8.DecodeRuneInString(s); r != utf8.RuneError && size == len(s) {
+		return r, nil
+	}
+	return 0, fmt.Errorf("bad character %q", s)
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"encoding/binary"
+	"errors"
+	"unicode/utf8"
+)
+
+const (
+	psf1_magic     = 0x0436
+	psf1_mode512   = 0x01
+	psf1_modeTable = 0x02
+	psf1_separator = 0xFFFE
+	psf1_end       = 0xFFFF
+
+	psf2_magic     = 0x864ab572
+	psf2_hasTable  = 0x01
+	psf2_separator = 0xFE
+	psf2_end       = 0xFF
+)
+
+// parsePSF reads a PC Screen Font of version 1 or 2. Glyphs are mapped to
+// characters by the unicode table of the font, by their index without one.
+func parsePSF(data []byte) (*font, error) {
+	if len(data) >= 4 && binary.LittleEndian.Uint16(data) == psf1_magic {
+		return parsePSF1(data)
+	}
+	if len(data) >= 32 && binary.LittleEndian.Uint32(data) == psf2_magic {
+		return parsePSF2(data)
+	}
+	return nil, errors.New("not a PSF font")
+}
+
+func parsePSF1(data []byte) (*font, error) {
+	mode, height := data[2], int(data[3])
+	count := 256
+	if mode&psf1_mode512 != 0 {
+		count = 512
+	}
+	end := 4 + count*height
+	if height == 0 || len(data) < end {
+		return nil, errors.New("truncated PSF font")
+	}
+	runes := make([][]rune, count)
+	if mode&psf1_modeTable != 0 {
+		table := data[end:]
+		for i := 0; i < count && len(table) >= 2; i++ {
+			seq := false
+			for len(table) >= 2 {
+				u := binary.LittleEndian.Uint16(table)
+				table = table[2:]
+				if u == psf1_end {
+					break
+				}
+				if u == psf1_separator {
+					seq = true
+				}
+				if !seq {
+					runes[i] = append(runes[i], rune(u))
+				}
+			}
+		}
+	}
+	return psfGlyphs(data[4:end], 8, height, count, runes), nil
+}
+
+func parsePSF2(data []byte) (*font, error) {
+	le := binary.LittleEndian
+	headerSize := int(le.Uint32(data[8:]))
+	flags := le.Uint32(data[12:])
+	count := int(le.Uint32(data[16:]))
+	glyphSize := int(le.Uint32(data[20:]))
+	height := int(le.Uint32(data[24:]))
+	width := int(le.Uint32(data[28:]))
+	if width == 0 || height == 0 || glyphSize != (width+7)/8*height {
+		return nil, errors.New("bad PSF header")
+	}
+	end := headerSize + count*glyphSize
+	if headerSize < 32 || len(data) < end {
+		return nil, errors.New("truncated PSF font")
+	}
+	runes := make([][]rune, count)
+	if flags&psf2_hasTable != 0 {
+		table := data[end:]
+		for i := 0; i < count && len(table) > 0; i++ {
+			seq := false
+			for len(table) > 0 {
+				c := table[0]
+				if c == psf2_end {
+					table = table[1:]
+					break
+				}
+				if c == psf2_separator {
+					seq = true
+					table = table[1:]
+					continue
+				}
+				r, size := utf8.DecodeRune(table)
+				table = table[size:]
+				if !seq {
+					runes[i] = append(runes[i], r)
+				}
+			}
+		}
+	}
+	return psfGlyphs(data[headerSize:end], width, height, count, runes), nil
+}
+
+// psfGlyphs unpacks count glyphs of rows padded to whole bytes, the glyph
+// at index i being drawn for runes[i], or for i if runes[i] is empty.
+func psfGlyphs(data []byte, width, height, count int, runes [][]rune) *font {
+	f := newFont(width, height)
+	stride := (width + 7) / 8
+	for i := 0; i < count; i++ {
+		rs := runes[i]
+		if len(rs) == 0 {
+			rs = []rune{rune(i)}
+		}
+		bitmap := data[i*stride*height:]
+		for _, r := range rs {
+			g := f.newGlyph(r)
+			for y := 0; y < height; y++ {
+				for x := 0; x < width; x++ {
+					if bitmap[y*stride+x/8]&(0x80>>uint(x%8)) != 0 {
+						f.set(g, x, y)
+					}
+				}
+			}
+		}
+	}
+	return f
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"bytes"
+	"image"
+	_ "image/png"
+)
+
+// parseSheet reads a PNG sheet of glyphs in cells of width*height pixels,
+// left to right and top to bottom from the glyph for first.
+func parseSheet(data []byte, width, height int, first rune) (*font, error) {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	f := newFont(width, height)
+	b := img.Bounds()
+	cols, rows := b.Dx()/width, b.Dy()/height
+	for i := 0; i < cols*rows; i++ {
+		x0 := b.Min.X + i%cols*width
+		y0 := b.Min.Y + i/cols*height
+		g := f.newGlyph(first + rune(i))
+		for y := 0; y < height; y++ {
+			for x := 0; x < width; x++ {
+				r, gr, bl, a := img.At(x0+x, y0+y).RGBA()
+				if a > 0x7fff && (r+gr+bl)/3 > a/2 {
+					f.set(g, x, y)
+				}
+			}
+		}
+	}
+	return f, nil
+}
@@ -1,10 +1,10 @@
 package common
 
 import (
-	"runtime/volatile"
+	"github.com/wencode/ubit/hal"
 )
 
-func Volatile32_GetAndClear(reg32 *volatile.Register32) uint32 {
+func Volatile32_GetAndClear(reg32 hal.Register32) uint32 {
 	v := reg32.Get()
 	if v != 0 {
 		reg32.Set(0)
@@ -5,9 +5,8 @@ import (
 	"sync"
 	"time"
 
-	"machine"
-
 	"github.com/wencode/ubit/font5x5"
+	"github.com/wencode/ubit/hal"
 	"github.com/wencode/ubit/image5x5"
 )
 
@@ -16,62 +15,83 @@ const (
 	display_height = 5
 )
 
+const (
+	// time each row of the matrix is driven during a scan
+	row_period = time.Microsecond * 2000
+
+	display_irq_priority = 2
+)
+
 const (
 	animTypeScroll = iota
+	animTypeFrames
+	animTypeTransition
 )
 
 type ModDisplay struct {
-	rowPins [5]machine.Pin
-	colPins [5]machine.Pin
+	rowPins [5]hal.Pin
+	colPins [5]hal.Pin
+	timer   hal.Timer
 
-	// runing at mono-core CPU, no data race problem
-	dirty  bool
-	buffer [display_width * display_height]uint8
-	quitch chan struct{}
-	quitWg sync.WaitGroup
+	// On the board bgloop and the program share a single core, and only
+	// the scan interrupt preempts them. The lock serialises them where
+	// they really run in parallel, see board_host.go.
+	displayLock
+	dirty    bool
+	batch    int32
+	autoSwap bool
+	buffer   [display_width * display_height]uint8
+	front    [display_width * display_height]uint8
+	quitch   chan struct{}
+	quitWg   sync.WaitGroup
+	wakech   chan struct{}
+	scan     scanState
+	sense    senseState
 
 	anim struct {
 		animType int32
 		elapse   int32
 		interval int32
-		count    int32
-	}
-	scroll struct {
-		value []byte
-		cur   int
+		done     chan struct{}
 	}
+	scroll     scrollState
+	frames     framesState
+	transition transitionState
+	plot       plotState
+	sprites    []*Sprite
+	font       font5x5.Font
+
+	// master brightness scaling every pixel, and whether the on-time of the
+	// leds follows gammaTable
+	level uint8
+	gamma bool
+	on    bool
 
 	rotation int32
+	mirrorH  bool
+	mirrorV  bool
+	orient   orientState
 	// for update
 	lastTime time.Time
 }
 
 func NewModDisplay() *ModDisplay {
+	rows, cols := newLedPins()
 	return &ModDisplay{
-		rowPins: [5]machine.Pin{
-			machine.LED_ROW_1,
-			machine.LED_ROW_2,
-			machine.LED_ROW_3,
-			machine.LED_ROW_4,
-			machine.LED_ROW_5,
-		},
-		colPins: [5]machine.Pin{
-			machine.LED_COL_1,
-			machine.LED_COL_2,
-			machine.LED_COL_3,
-			machine.LED_COL_4,
-			machine.LED_COL_5,
-		},
-		quitch: make(chan struct{}),
+		rowPins:  rows,
+		colPins:  cols,
+		timer:    newScanTimer(),
+		quitch:   make(chan struct{}),
+		wakech:   make(chan struct{}, 1),
+		level:    255,
+		autoSwap: true,
+		font:     font5x5.Default,
 	}
 }
 
 func (d *ModDisplay) Init() {
-	for i := 0; i < 5; i++ {
-		d.rowPins[i].Configure(machine.PinConfig{Mode: machine.PinOutput})
-		d.colPins[i].Configure(machine.PinConfig{Mode: machine.PinOutput})
-	}
 	d.Clear()
+	d.On()
 	go d.bgloop()
 }
 
@@ -79,23 +99,129 @@ func (d *ModDisplay) Uninit() {
 	d.quitWg.Add(1)
 	d.quitch <- struct{}{}
 	d.quitWg.Wait()
+	d.Off()
 }
 
+// On takes the row and column pins back and starts scanning the frame
+// buffer again, showing what was on the display before Off.
+func (d *ModDisplay) On() {
+	if d.on {
+		return
+	}
+	for i := 0; i < 5; i++ {
+		d.rowPins[i].Configure(hal.PinOutput)
+		d.colPins[i].Configure(hal.PinOutput)
+	}
+	d.blank()
+	d.scanStart()
+	d.on = true
+}
+
+// Off stops scanning and releases the row and column pins, so they can be
+// used as GPIO. The frame buffer and animations are kept.
+func (d *ModDisplay) Off() {
+	if !d.on {
+		return
+	}
+	d.scanStop()
+	for i := 0; i < 5; i++ {
+		d.rowPins[i].Configure(hal.PinRelease)
+		d.colPins[i].Configure(hal.PinRelease)
+	}
+	d.on = false
+}
+
+func (d *ModDisplay) IsOn() bool { return d.on }
+
+// Size returns the size of the led matrix in pixels.
+func (d *ModDisplay) Size() (x, y int16) {
+	return display_width, display_height
+}
+
+// SetPixel sets the brightness of a pixel from the luminance of c, scaled by
+// its alpha. Together with Size and Display, it lets ModDisplay be used as a
+// TinyGo drivers.Displayer.
 func (d *ModDisplay) SetPixel(x, y int16, c color.RGBA) {
+	lum := (299*uint32(c.R) + 587*uint32(c.G) + 114*uint32(c.B)) / 1000
+	d.SetBrightness(x, y, uint8(lum*uint32(c.A)/255))
+}
 
+// GetPixel returns the pixel at x, y as an opaque grey.
+func (d *ModDisplay) GetPixel(x, y int16) color.RGBA {
+	v := d.GetBrightness(x, y)
+	return color.RGBA{R: v, G: v, B: v, A: 255}
+}
+
+// Display shows the frame buffer from the next frame on. It is needed only
+// when automatic swapping is off, see SetAutoSwap.
+func (d *ModDisplay) Display() error {
+	d.lock()
+	d.dirty = true
+	d.unlock()
+	return nil
 }
 
 func (d *ModDisplay) SetBrightness(x, y int16, value uint8) {
-	if x < 0 || x > display_width {
-		return
-	}
-	if y < 0 || y > display_height {
+	d.lock()
+	d.setBrightness(x, y, value)
+	d.unlock()
+}
+
+func (d *ModDisplay) setBrightness(x, y int16, value uint8) {
+	if !inDisplay(x, y) {
 		return
 	}
 	d.buffer[y*display_width+x] = value
+	d.changed()
+}
+
+func (d *ModDisplay) GetBrightness(x, y int16) uint8 {
+	if !inDisplay(x, y) {
+		return 0
+	}
+	d.lock()
+	defer d.unlock()
+	return d.buffer[y*display_width+x]
+}
+
+// SetLevel sets the master brightness, which scales every pixel without
+// changing the frame buffer. 0 turns all leds off.
+func (d *ModDisplay) SetLevel(level uint8) {
+	d.lock()
+	d.level = level
+	d.unlock()
+}
+
+func (d *ModDisplay) Level() uint8 { return d.level }
+
+// SetGamma turns gamma correction on or off. With it, a pixel of 128 looks
+// about half as bright as one of 255.
+func (d *ModDisplay) SetGamma(enable bool) {
+	d.lock()
+	d.gamma = enable
+	d.unlock()
+}
+
+func inDisplay(x, y int16) bool {
+	return x >= 0 && x < display_width && y >= 0 && y < display_height
 }
 
 func (d *ModDisplay) Clear() {
+	d.lock()
+	d.clear()
+	d.unlock()
+}
+
+func (d *ModDisplay) clear() {
+	d.begin()
+	for i := range d.buffer {
+		d.buffer[i] = 0
+	}
+	d.end()
+}
+
+// blank switches every led off without touching the frame buffer.
+func (d *ModDisplay) blank() {
 	for i := 0; i < 5; i++ {
 		d.rowPins[i].Low()
 		d.colPins[i].High()
@@ -103,58 +229,122 @@ func (d *ModDisplay) Clear() {
 }
 
 func (d *ModDisplay) Show(img image5x5.Image) {
+	d.lock()
+	d.show(img)
+	d.unlock()
+}
+
+func (d *ModDisplay) show(img image5x5.Image) {
+	d.begin()
 	copy(d.buffer[:], []uint8(img[:]))
+	d.end()
 }
 
-func (d *ModDisplay) ShowCharacter(c byte) {
-	d.Show(font5x5.GenImage5x5(c, 255))
+// ShowBitmap shows the 5x5 part of b at x, y.
+func (d *ModDisplay) ShowBitmap(b *image5x5.Bitmap, x, y int) {
+	d.Show(b.Image(x, y))
 }
 
-func (d *ModDisplay) Scroll(img image5x5.Image) {
-	d.anim.animType = animTypeScroll
-	d.anim.elapse = 0
-	d.anim.interval = 1000
-	d.anim.count = display_width
-	d.scroll.value = nil
-	d.scroll.cur = 0
-	d.Show(img)
+// ShowCharacter shows the glyph for c in the font set with SetFont, or '?'
+// if the font has none.
+func (d *ModDisplay) ShowCharacter(c rune) {
+	d.lock()
+	d.show(glyphImage(d.font, c))
+	d.unlock()
 }
 
-func (d *ModDisplay) ScrollText(text string) {
-	d.anim.animType = animTypeScroll
-	d.anim.elapse = 0
-	d.anim.interval = 1000
-	d.anim.count = display_width - 1
-	d.scroll.value = []byte(text)
-	d.scroll.cur = 0
-	d.ShowCharacter(d.scroll.value[0])
+// SetFont sets the font of ShowCharacter and of ScrollText, nil restores
+// font5x5.Default.
+func (d *ModDisplay) SetFont(f font5x5.Font) {
+	if f == nil {
+		f = font5x5.Default
+	}
+	d.lock()
+	d.font = f
+	d.unlock()
+}
+
+func glyphImage(f font5x5.Font, c rune) image5x5.Image {
+	img, ok := f.Glyph(c)
+	if !ok {
+		img, _ = f.Glyph('?')
+	}
+	return img
 }
 
+// Rotate turns what is shown by num_ccw quarter turns counter-clockwise,
+// negative values turn clockwise. Earlier versions turned the other way,
+// callers of Rotate(1) and Rotate(3) for a clockwise turn must swap them.
 func (d *ModDisplay) Rotate(num_ccw int) {
-	d.rotation = int32(num_ccw) % 4
+	d.lock()
+	d.rotation = int32((num_ccw%4 + 4) % 4)
+	d.unlock()
 }
 
+// bgloop drives the animations and the auto orientation. Scanning the
+// matrix is done by the timer interrupt, so the loop only wakes up when the
+// next step is due, or when woken by a new animation, and blocks while
+// there is nothing to do.
 func (d *ModDisplay) bgloop() {
-	d.lastTime = time.Now()
+	timer := time.NewTimer(time.Hour)
+	stopTimer(timer)
 LOOP:
 	for {
+		d.lock()
+		wait := d.anim.interval - d.anim.elapse
+		if d.anim.interval <= 0 {
+			wait = -1
+		}
+		if d.orient.acc != nil {
+			if owait := orient_interval - d.orient.elapse; wait < 0 || owait < wait {
+				wait = owait
+			}
+		}
+		d.unlock()
+
+		var timeout <-chan time.Time
+		if wait >= 0 {
+			timer.Reset(time.Duration(wait) * time.Millisecond)
+			timeout = timer.C
+		}
 		select {
 		case <-d.quitch:
+			stopTimer(timer)
 			break LOOP
-		default:
+		case <-d.wakech:
+			stopTimer(timer)
+		case <-timeout:
 		}
 
+		d.lock()
 		now := time.Now()
 		diff := int32(now.Sub(d.lastTime).Milliseconds())
-		d.animUpdate(diff)
 		d.lastTime = now
-
-		d.render()
+		d.animUpdate(diff)
+		d.orientUpdate(diff)
+		d.unlock()
 	}
-	d.Clear()
 	d.quitWg.Done()
 }
 
+// stopTimer stops t and drains its channel, so that it can be Reset.
+func stopTimer(t *time.Timer) {
+	if !t.Stop() {
+		select {
+		case <-t.C:
+		default:
+		}
+	}
+}
+
+// wake restarts bgloop after a new animation has been set up.
+func (d *ModDisplay) wake() {
+	select {
+	case d.wakech <- struct{}{}:
+	default:
+	}
+}
+
 func (d *ModDisplay) animUpdate(diff int32) {
 	if d.anim.interval <= 0 {
 		return
@@ -163,62 +353,88 @@ func (d *ModDisplay) animUpdate(diff int32) {
 	if d.anim.elapse < d.anim.interval {
 		return
 	}
+	// time since the previous step, longer than interval when late
+	elapse := d.anim.elapse
 	d.anim.elapse = 0
-	d.anim.count--
-	if d.anim.count == 0 {
-		d.animEnd()
-		return
-	}
-	if d.anim.animType == animTypeScroll {
+	switch d.anim.animType {
+	case animTypeScroll:
 		d.scrollUpdate()
+	case animTypeFrames:
+		d.framesUpdate()
+	case animTypeTransition:
+		d.transitionUpdate(elapse)
 	}
 }
 
-func (d *ModDisplay) scrollUpdate() {
-	copy(d.buffer[:], d.buffer[1:])
-	for i := 1; i < display_height; i++ {
-		d.buffer[i*display_width-1] = 0
-	}
+// IsAnimating reports whether a scroll or another animation is running.
+func (d *ModDisplay) IsAnimating() bool {
+	d.lock()
+	defer d.unlock()
+	return d.anim.interval > 0
 }
 
-func (d *ModDisplay) animEnd() {
-	if d.anim.animType == animTypeScroll && d.scroll.value != nil {
-		d.scroll.cur++
-		if d.scroll.cur < len(d.scroll.value) {
-			d.anim.count = display_width - 1
-			d.ShowCharacter(d.scroll.value[d.scroll.cur])
-		}
+// StopAnimation cancels the running animation, leaving its current frame on
+// the display.
+func (d *ModDisplay) StopAnimation() {
+	d.lock()
+	d.stopAnimation()
+	d.unlock()
+}
+
+func (d *ModDisplay) stopAnimation() {
+	if d.anim.interval > 0 {
+		d.animEnd()
 	}
 }
 
-func (d *ModDisplay) render() {
-	for y := 0; y < display_height; y++ {
-		d.Clear()
-		d.rowPins[y].High()
+// animStart replaces the running animation, which counts as stopped, and
+// returns the done channel of the new one. bgloop picks it up once woken.
+func (d *ModDisplay) animStart(animType int32, interval int32) chan struct{} {
+	d.animEnd()
+	if interval <= 0 {
+		interval = 1
+	}
+	d.anim.animType = animType
+	d.anim.elapse = 0
+	d.anim.interval = interval
+	// the first step is due interval from now, not from the last update
+	d.lastTime = time.Now()
+	d.anim.done = make(chan struct{})
+	d.wake()
+	return d.anim.done
+}
 
-		for x := 0; x < display_width; x++ {
-			idx := d.bufferIndex(x, y)
-			if d.buffer[idx] != 0 {
-				d.colPins[x].Low()
-			}
-		}
-		time.Sleep(time.Millisecond * 2)
+func (d *ModDisplay) animEnd() {
+	d.anim.interval = 0
+	d.scroll.bitmap = nil
+	d.frames.images = nil
+	if d.anim.done != nil {
+		close(d.anim.done)
+		d.anim.done = nil
 	}
 }
 
+// bufferIndex maps a led of the matrix to the pixel of the frame buffer it
+// shows, mirroring and then rotating the display.
 func (d *ModDisplay) bufferIndex(x, y int) int {
+	if d.mirrorH {
+		x = display_width - 1 - x
+	}
+	if d.mirrorV {
+		y = display_height - 1 - y
+	}
 	x0 := x
 	y0 := y
 	switch d.rotation {
 	case 1:
-		x0 = y
-		y0 = display_width - 1 - x
+		x0 = display_height - 1 - y
+		y0 = x
 	case 2:
 		x0 = display_width - 1 - x
 		y0 = display_height - 1 - y
 	case 3:
-		x0 = display_height - 1 - y
-		y0 = x
+		x0 = y
+		y0 = display_width - 1 - x
 	}
 	return y0*display_width + x0
 }
@@ -0,0 +1,114 @@
+package ubit
+
+import (
+	"github.com/wencode/ubit/font5x5"
+)
+
+// Direction in which an animation moves the content of the display.
+type Direction int32
+
+const (
+	DirLeft Direction = iota
+	DirRight
+	DirUp
+	DirDown
+)
+
+// PlayMode tells how the frames of Animate are played.
+type PlayMode int32
+
+const (
+	// PlayOnce shows every frame once
+	PlayOnce PlayMode = iota
+	// PlayLoop starts again from the first frame after the last one
+	PlayLoop
+	// PlayPingPong plays the frames forwards and then backwards
+	PlayPingPong
+)
+
+const (
+	// RepeatForever keeps an animation running until another one replaces it
+	RepeatForever = -1
+)
+
+type AnimConfig struct {
+	delay     int32
+	direction Direction
+	// 0 leaves the number of runs to the animation, see WithRepeat
+	repeat    int
+	lead      int
+	trail     int
+	mode      PlayMode
+	durations []int32
+	// nil for the font of the display
+	font font5x5.Font
+}
+
+func anim_defaultConfig(delay int32) AnimConfig {
+	return AnimConfig{
+		delay:     delay,
+		direction: DirLeft,
+		lead:      -1,
+		trail:     -1,
+		mode:      PlayOnce,
+	}
+}
+
+type AnimOption func(*AnimConfig)
+
+// WithDelay sets the milliseconds an animation stays on each step.
+func WithDelay(ms int) AnimOption {
+	return func(cfg *AnimConfig) {
+		cfg.delay = int32(ms)
+	}
+}
+
+func WithDirection(dir Direction) AnimOption {
+	return func(cfg *AnimConfig) {
+		cfg.direction = dir
+	}
+}
+
+// WithRepeat plays an animation count times, or until it is replaced when
+// count is RepeatForever. Scrolls and PlayOnce run once by default, PlayLoop
+// and PlayPingPong forever.
+func WithRepeat(count int) AnimOption {
+	return func(cfg *AnimConfig) {
+		cfg.repeat = count
+	}
+}
+
+// WithGap sets the number of blank columns (or rows when scrolling up and
+// down) shown before and after the scrolled content.
+func WithGap(leading, trailing int) AnimOption {
+	return func(cfg *AnimConfig) {
+		cfg.lead = leading
+		cfg.trail = trailing
+	}
+}
+
+// WithMode sets how Animate plays its frames.
+func WithMode(mode PlayMode) AnimOption {
+	return func(cfg *AnimConfig) {
+		cfg.mode = mode
+	}
+}
+
+// WithFrameDurations sets the milliseconds each frame of Animate is shown,
+// frames beyond the given durations use the delay of WithDelay.
+func WithFrameDurations(ms ...int) AnimOption {
+	return func(cfg *AnimConfig) {
+		cfg.durations = make([]int32, len(ms))
+		for i, v := range ms {
+			cfg.durations[i] = int32(v)
+		}
+	}
+}
+
+// WithFont sets the font of ScrollText, instead of the one set with
+// SetFont.
+func WithFont(f font5x5.Font) AnimOption {
+	return func(cfg *AnimConfig) {
+		cfg.font = f
+	}
+}
@@ -0,0 +1,61 @@
+package ubit
+
+// Everything draws into buffer, the back buffer, while the row scan reads
+// front. buffer is copied to front, with the sprites on top, at the start of
+// a frame when it is dirty and no batch of updates is open, so the scan
+// never shows half an update.
+
+// Begin opens a batch of updates, nothing drawn from now on shows up until
+// the matching Commit. Batches may be nested.
+func (d *ModDisplay) Begin() {
+	d.lock()
+	d.begin()
+	d.unlock()
+}
+
+// Commit closes a batch opened by Begin. Once the last batch is closed, the
+// frame buffer is shown from the next frame on.
+func (d *ModDisplay) Commit() {
+	d.lock()
+	if d.batch > 0 {
+		d.batch--
+	}
+	d.dirty = true
+	d.unlock()
+}
+
+// SetAutoSwap turns automatic swapping of the frame buffers on (the
+// default) or off. With it off, drawing shows up only after Display or
+// Commit.
+func (d *ModDisplay) SetAutoSwap(enable bool) {
+	d.lock()
+	d.autoSwap = enable
+	d.unlock()
+}
+
+// begin and end wrap every change of more than one pixel.
+func (d *ModDisplay) begin() {
+	d.batch++
+}
+
+func (d *ModDisplay) end() {
+	if d.batch > 0 {
+		d.batch--
+	}
+	d.changed()
+}
+
+func (d *ModDisplay) changed() {
+	if d.autoSwap {
+		d.dirty = true
+	}
+}
+
+// swap runs in the scan interrupt at the start of every frame.
+func (d *ModDisplay) swap() {
+	if d.dirty && d.batch == 0 {
+		d.front = d.buffer
+		d.composite(&d.front)
+		d.dirty = false
+	}
+}
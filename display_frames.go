@@ -0,0 +1,104 @@
+package ubit
+
+import (
+	"github.com/wencode/ubit/image5x5"
+)
+
+const (
+	// milliseconds each frame of Animate is shown, same as MicroPython
+	frame_interval = 400
+)
+
+type framesState struct {
+	images    []image5x5.Image
+	durations []int32
+	delay     int32
+	pingpong  bool
+	cur       int
+	step      int
+	repeat    int
+}
+
+// Animate shows frames one after another, see WithMode, WithDelay and
+// WithFrameDurations for how they are played.
+func (d *ModDisplay) Animate(frames []image5x5.Image, opts ...AnimOption) {
+	d.AnimateAsync(frames, opts...)
+}
+
+// AnimateWait is like Animate but returns only after the last frame has been
+// shown. Looping animations never return unless limited by WithRepeat.
+func (d *ModDisplay) AnimateWait(frames []image5x5.Image, opts ...AnimOption) {
+	<-d.AnimateAsync(frames, opts...)
+}
+
+// AnimateAsync is like Animate and returns a channel that is closed once the
+// animation has finished or has been stopped.
+func (d *ModDisplay) AnimateAsync(frames []image5x5.Image, opts ...AnimOption) <-chan struct{} {
+	cfg := anim_defaultConfig(frame_interval)
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	d.lock()
+	defer d.unlock()
+	done := d.animStart(animTypeFrames, cfg.delay)
+	if len(frames) == 0 {
+		d.animEnd()
+		return done
+	}
+
+	f := &d.frames
+	f.images = frames
+	f.durations = cfg.durations
+	f.delay = cfg.delay
+	f.pingpong = cfg.mode == PlayPingPong && len(frames) > 1
+	f.cur = 0
+	f.step = 1
+	f.repeat = cfg.repeat
+	if f.repeat == 0 {
+		f.repeat = 1
+		if cfg.mode != PlayOnce {
+			f.repeat = RepeatForever
+		}
+	}
+	d.frameShow()
+	return done
+}
+
+func (d *ModDisplay) framesUpdate() {
+	f := &d.frames
+	next := f.cur + f.step
+	if next >= len(f.images) && f.pingpong {
+		f.step = -1
+		next = f.cur - 1
+	}
+	if next < 0 || next >= len(f.images) {
+		if f.repeat > 0 {
+			f.repeat--
+		}
+		if f.repeat == 0 {
+			d.animEnd()
+			return
+		}
+		// a ping-pong is back on the first frame, go on with the second
+		f.step = 1
+		next = 0
+		if f.pingpong {
+			next = 1
+		}
+	}
+	f.cur = next
+	d.frameShow()
+}
+
+func (d *ModDisplay) frameShow() {
+	f := &d.frames
+	d.show(f.images[f.cur])
+	d.anim.interval = f.delay
+	if f.cur < len(f.durations) {
+		d.anim.interval = f.durations[f.cur]
+	}
+	if d.anim.interval <= 0 {
+		d.anim.interval = 1
+	}
+}
@@ -0,0 +1,100 @@
+package ubit
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/wencode/ubit/image5x5"
+)
+
+// numberedFrames returns n frames, frame i lighting pixel i.
+func numberedFrames(n int) []image5x5.Image {
+	frames := make([]image5x5.Image, n)
+	for i := range frames {
+		frames[i][i] = 255
+	}
+	return frames
+}
+
+func TestDisplayAnimate(t *testing.T) {
+	cases := []struct {
+		name string
+		n    int
+		opts []AnimOption
+		// frames shown, and their intervals when not nil, until the
+		// animation ends or for 12 steps
+		want      string
+		intervals []int32
+		ends      bool
+	}{
+		{"once", 3, nil, "012", []int32{400, 400, 400}, true},
+		{"loop twice", 3, []AnimOption{WithMode(PlayLoop), WithRepeat(2)}, "012012", nil, true},
+		{"loop forever", 2, []AnimOption{WithMode(PlayLoop)}, "010101010101", nil, false},
+		{"ping-pong twice", 3, []AnimOption{WithMode(PlayPingPong), WithRepeat(2)}, "012101210", nil, true},
+		{"ping-pong forever", 3, []AnimOption{WithMode(PlayPingPong)}, "012101210121", nil, false},
+		{"ping-pong one frame", 1, []AnimOption{WithMode(PlayPingPong), WithRepeat(3)}, "000", nil, true},
+		{"durations", 3, []AnimOption{WithFrameDurations(10, 20, 30)}, "012", []int32{10, 20, 30}, true},
+		{"durations and delay", 3, []AnimOption{WithDelay(50), WithFrameDurations(10)}, "012", []int32{10, 50, 50}, true},
+		{"zero duration", 2, []AnimOption{WithFrameDurations(0, 5)}, "01", []int32{1, 5}, true},
+	}
+	for _, c := range cases {
+		d, _ := newTestDisplay(t)
+		frames := numberedFrames(c.n)
+		done := d.AnimateAsync(frames, c.opts...)
+
+		got := ""
+		var intervals []int32
+		for step := 0; step < 12 && d.IsAnimating(); step++ {
+			shown := "?"
+			for i, f := range frames {
+				if d.buffer == f {
+					shown = strconv.Itoa(i)
+				}
+			}
+			got += shown
+			intervals = append(intervals, d.anim.interval)
+			d.animUpdate(d.anim.interval)
+		}
+		if got != c.want {
+			t.Errorf("%s: frames %s, want %s", c.name, got, c.want)
+		}
+		if c.intervals != nil && !equalInt32(intervals, c.intervals) {
+			t.Errorf("%s: intervals %v, want %v", c.name, intervals, c.intervals)
+		}
+		ended := false
+		select {
+		case <-done:
+			ended = true
+		default:
+		}
+		if ended != c.ends {
+			t.Errorf("%s: ended %v, want %v", c.name, ended, c.ends)
+		}
+		d.StopAnimation()
+	}
+}
+
+func TestDisplayAnimateEmpty(t *testing.T) {
+	d, _ := newTestDisplay(t)
+	done := d.AnimateAsync(nil)
+	if d.IsAnimating() {
+		t.Error("animating without frames")
+	}
+	select {
+	case <-done:
+	default:
+		t.Error("done not closed")
+	}
+}
+
+func equalInt32(a, b []int32) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
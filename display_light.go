@@ -0,0 +1,108 @@
+package ubit
+
+import (
+	"github.com/wencode/ubit/hal"
+)
+
+// The leds of the matrix double as light sensors. With the rows low and the
+// columns high they are reverse biased and charge up like small capacitors,
+// then left floating, light discharges them. A sense row period first
+// charges every led until scan_charge_ticks, then lets the sense columns
+// float. The voltage left on them at scan_sense_ticks drops as the light
+// gets brighter.
+const (
+	scan_charge_ticks = scan_row_ticks / 4
+	scan_sense_ticks  = scan_row_ticks * 3 / 4
+	// a sense row period is added to one frame out of sense_frame_period
+	sense_frame_period = 4
+	// frames the sensor keeps measuring after the last ReadLightLevel,
+	// about half a second
+	sense_idle_frames = 50
+)
+
+// columns of the matrix connected to an analog input
+var senseColumns = [...]int{0, 2, 4}
+
+type senseState struct {
+	enabled bool
+	adc     [len(senseColumns)]hal.ADC
+	level   uint8
+	// frames left to measure, the sense row is scanned while non zero
+	frames uint16
+	// counts frames to add the sense row to one of sense_frame_period
+	frame uint8
+	// whether the sense columns float, after the charge
+	floating bool
+}
+
+// ReadLightLevel returns the ambient light from 0 (dark) to 255 (bright).
+// The very first call turns the sensor on and returns 0, as nothing has been
+// measured yet. A measurement is then taken every fourth frame until
+// ReadLightLevel has not been called for about half a second, the next call
+// after such a pause returns the last level measured. All leds are off for
+// the row period of a measurement, which dims the display by about 5% and
+// slows its refresh by as much while measuring.
+func (d *ModDisplay) ReadLightLevel() uint8 {
+	d.lock()
+	defer d.unlock()
+	if !d.sense.enabled {
+		for i, x := range senseColumns {
+			d.sense.adc[i] = newSenseADC(x)
+		}
+		d.sense.enabled = true
+	}
+	d.sense.frames = sense_idle_frames
+	return d.sense.level
+}
+
+// senseDue reports whether the frame about to end is followed by a sense
+// row period.
+func (d *ModDisplay) senseDue() bool {
+	return d.sense.frames > 0 && d.sense.frame == 0
+}
+
+// senseFrame counts a frame starting.
+func (d *ModDisplay) senseFrame() {
+	if d.sense.frames > 0 {
+		d.sense.frames--
+	}
+	d.sense.frame = (d.sense.frame + 1) % sense_frame_period
+}
+
+// senseStart begins the sense row period by charging the leds, scanRow has
+// just driven the rows low and the columns high.
+func (d *ModDisplay) senseStart() {
+	for x := range d.scan.offTick {
+		d.scan.offTick[x] = 0
+	}
+	d.sense.floating = false
+	d.scanSetOff(scan_charge_ticks)
+}
+
+// senseStep lets the sense columns float once the leds are charged, and
+// reads them at the end of the measurement.
+func (d *ModDisplay) senseStep() {
+	if !d.sense.floating {
+		for _, x := range senseColumns {
+			d.colPins[x].Configure(hal.PinRelease)
+		}
+		d.sense.floating = true
+		d.scanSetOff(scan_sense_ticks)
+		return
+	}
+	d.senseEnd()
+}
+
+func (d *ModDisplay) senseEnd() {
+	sum := uint32(0)
+	for i, x := range senseColumns {
+		sum += uint32(d.sense.adc[i].Get())
+		d.colPins[x].Configure(hal.PinOutput)
+		d.colPins[x].High()
+	}
+	level := 255 - sum/uint32(len(senseColumns))>>8
+	// smooth out the noise of single measurements
+	d.sense.level = uint8((uint32(d.sense.level)*3 + level) / 4)
+	d.sense.floating = false
+	d.scanSetOff(scan_no_ticks)
+}
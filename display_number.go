@@ -0,0 +1,59 @@
+package ubit
+
+import (
+	"strconv"
+
+	"github.com/wencode/ubit/common"
+	"github.com/wencode/ubit/font5x5"
+)
+
+// ShowNumber shows a single digit on the display and scrolls longer numbers
+// across it.
+func (d *ModDisplay) ShowNumber(n int, opts ...AnimOption) {
+	d.showValue(strconv.Itoa(n), opts...)
+}
+
+// ShowFloat shows f with the given number of decimals, scrolling it unless
+// it fits on the display as a single digit.
+func (d *ModDisplay) ShowFloat(f float64, decimals int, opts ...AnimOption) {
+	d.showValue(strconv.FormatFloat(f, 'f', decimals, 64), opts...)
+}
+
+func (d *ModDisplay) showValue(s string, opts ...AnimOption) {
+	if len(s) == 1 {
+		d.StopAnimation()
+		d.ShowCharacter(rune(s[0]))
+		return
+	}
+	d.ScrollText(s, opts...)
+}
+
+// ShowNumberCompact shows n from 0 to 99 without scrolling, as two narrow
+// digits side by side.
+func (d *ModDisplay) ShowNumberCompact(n int) error {
+	if n < 0 || n > 99 {
+		return common.ErrInvalidArgument
+	}
+	d.lock()
+	defer d.unlock()
+	d.stopAnimation()
+	d.begin()
+	d.clear()
+	if n >= 10 {
+		d.showDigit2x5(n/10, 0)
+	}
+	d.showDigit2x5(n%10, display_width-2)
+	d.end()
+	return nil
+}
+
+func (d *ModDisplay) showDigit2x5(n int, x0 int) {
+	data := font5x5.GetDigit2x5(n)
+	for y := 0; y < display_height; y++ {
+		for x := 0; x < 2; x++ {
+			if (data[y]>>(1-x))&1 != 0 {
+				d.buffer[y*display_width+x0+x] = 255
+			}
+		}
+	}
+}
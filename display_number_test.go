@@ -0,0 +1,78 @@
+package ubit
+
+import (
+	"testing"
+
+	"github.com/wencode/ubit/common"
+	"github.com/wencode/ubit/font5x5"
+	"github.com/wencode/ubit/image5x5"
+)
+
+func TestShowNumberCompact(t *testing.T) {
+	cases := []struct {
+		n    int
+		want string
+		err  error
+	}{
+		{0, "00099:00099:00099:00099:00099", nil},
+		{7, "00099:00009:00009:00009:00009", nil},
+		{10, "09099:09099:09099:09099:09099", nil},
+		{42, "90099:90009:99099:09090:09099", nil},
+		{99, "99099:99099:99099:09009:09009", nil},
+		{-1, "", common.ErrInvalidArgument},
+		{100, "", common.ErrInvalidArgument},
+	}
+	for _, c := range cases {
+		d, _ := newTestDisplay(t)
+		d.Show(filled(10))
+		d.ScrollText("hello")
+		err := d.ShowNumberCompact(c.n)
+		if err != c.err {
+			t.Errorf("ShowNumberCompact(%d): error %v, want %v", c.n, err, c.err)
+			continue
+		}
+		if err != nil {
+			if !d.IsAnimating() {
+				t.Errorf("ShowNumberCompact(%d) stopped the scroll", c.n)
+			}
+			continue
+		}
+		if d.IsAnimating() {
+			t.Errorf("ShowNumberCompact(%d) left the scroll running", c.n)
+		}
+		if got := image5x5.Image(d.buffer).String(); got != c.want {
+			t.Errorf("ShowNumberCompact(%d): %s, want %s", c.n, got, c.want)
+		}
+	}
+}
+
+func TestShowNumberStatic(t *testing.T) {
+	cases := []struct {
+		name      string
+		show      func(d *ModDisplay)
+		static    bool
+		character rune
+	}{
+		{"digit", func(d *ModDisplay) { d.ShowNumber(5) }, true, '5'},
+		{"zero", func(d *ModDisplay) { d.ShowNumber(0) }, true, '0'},
+		{"float rounded", func(d *ModDisplay) { d.ShowFloat(3.4, 0) }, true, '3'},
+		{"two digits", func(d *ModDisplay) { d.ShowNumber(12) }, false, 0},
+		{"negative", func(d *ModDisplay) { d.ShowNumber(-3) }, false, 0},
+		{"decimals", func(d *ModDisplay) { d.ShowFloat(3.4, 1) }, false, 0},
+	}
+	for _, c := range cases {
+		d, _ := newTestDisplay(t)
+		d.ScrollText("hello")
+		c.show(d)
+		if d.IsAnimating() == c.static {
+			t.Errorf("%s: animating %v, want %v", c.name, d.IsAnimating(), !c.static)
+		}
+		if !c.static {
+			continue
+		}
+		glyph, _ := font5x5.Default.Glyph(c.character)
+		if d.buffer != glyph {
+			t.Errorf("%s: %s, want the glyph of %c", c.name, image5x5.Image(d.buffer), c.character)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package ubit
+
+const (
+	// milliseconds between two readings of the accelerometer for AutoOrient
+	orient_interval = 200
+)
+
+// Accelerometer is implemented by accelerometer drivers such as the TinyGo
+// lsm303agr driver. x grows towards the right edge of the display and y
+// towards its bottom edge (the edge connector) as that edge points down.
+type Accelerometer interface {
+	ReadAcceleration() (x, y, z int32, err error)
+}
+
+type orientState struct {
+	acc    Accelerometer
+	elapse int32
+}
+
+// Mirror flips what is shown left to right and/or top to bottom, on top of
+// the rotation set by Rotate.
+func (d *ModDisplay) Mirror(horizontal, vertical bool) {
+	d.lock()
+	d.mirrorH = horizontal
+	d.mirrorV = vertical
+	d.unlock()
+}
+
+// Rotation returns the number of counter-clockwise quarter turns, 0 to 3.
+func (d *ModDisplay) Rotation() int {
+	d.lock()
+	defer d.unlock()
+	return int(d.rotation)
+}
+
+// AutoOrient keeps the bottom of what is shown on the lowest edge of the
+// board, reading acc a few times per second. The rotation is kept while the
+// board lies flat. A nil acc turns auto orientation off.
+func (d *ModDisplay) AutoOrient(acc Accelerometer) {
+	d.lock()
+	d.orient.acc = acc
+	d.orient.elapse = orient_interval
+	d.unlock()
+	d.wake()
+}
+
+func (d *ModDisplay) orientUpdate(diff int32) {
+	if d.orient.acc == nil {
+		return
+	}
+	d.orient.elapse += diff
+	if d.orient.elapse < orient_interval {
+		return
+	}
+	d.orient.elapse = 0
+
+	x, y, z, err := d.orient.acc.ReadAcceleration()
+	if err != nil {
+		return
+	}
+	if rotation, ok := orientRotation(x, y, z); ok {
+		d.rotation = rotation
+	}
+}
+
+// orientRotation returns the rotation for gravity along x, y, z. It fails
+// unless one of x and y clearly dominates, so that the display does not
+// flip back and forth around the diagonals or while lying flat.
+func orientRotation(x, y, z int32) (int32, bool) {
+	ax, ay, az := abs32(x), abs32(y), abs32(z)
+	switch {
+	case ay > 2*ax && ay > az:
+		if y > 0 {
+			return 0, true
+		}
+		return 2, true
+	case ax > 2*ay && ax > az:
+		if x > 0 {
+			return 1, true
+		}
+		return 3, true
+	}
+	return 0, false
+}
+
+func abs32(v int32) int32 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,92 @@
+package ubit
+
+import (
+	"testing"
+
+	"github.com/wencode/ubit/image5x5"
+)
+
+// shown returns the image on the leds, each showing the pixel of the frame
+// buffer given by bufferIndex.
+func shown(d *ModDisplay) image5x5.Image {
+	var img image5x5.Image
+	for y := 0; y < display_height; y++ {
+		for x := 0; x < display_width; x++ {
+			img[y*display_width+x] = d.buffer[d.bufferIndex(x, y)]
+		}
+	}
+	return img
+}
+
+func TestDisplayRotateMirror(t *testing.T) {
+	// a bright top left pixel, and a dimmer one on its right
+	img := image5x5.MustParse("95000:00000:00000:00000:00000")
+	cases := []struct {
+		numCCW           int
+		mirrorH, mirrorV bool
+		want             string
+	}{
+		{-1, false, false, "00009:00005:00000:00000:00000"},
+		{-1, true, false, "90000:50000:00000:00000:00000"},
+		{-1, false, true, "00000:00000:00000:00005:00009"},
+		{-1, true, true, "00000:00000:00000:50000:90000"},
+		{0, false, false, "95000:00000:00000:00000:00000"},
+		{0, true, false, "00059:00000:00000:00000:00000"},
+		{0, false, true, "00000:00000:00000:00000:95000"},
+		{0, true, true, "00000:00000:00000:00000:00059"},
+		{1, false, false, "00000:00000:00000:50000:90000"},
+		{1, true, false, "00000:00000:00000:00005:00009"},
+		{1, false, true, "90000:50000:00000:00000:00000"},
+		{1, true, true, "00009:00005:00000:00000:00000"},
+		{2, false, false, "00000:00000:00000:00000:00059"},
+		{2, true, false, "00000:00000:00000:00000:95000"},
+		{2, false, true, "00059:00000:00000:00000:00000"},
+		{2, true, true, "95000:00000:00000:00000:00000"},
+		{3, false, false, "00009:00005:00000:00000:00000"},
+		{3, true, false, "90000:50000:00000:00000:00000"},
+		{3, false, true, "00000:00000:00000:00005:00009"},
+		{3, true, true, "00000:00000:00000:50000:90000"},
+		{4, false, false, "95000:00000:00000:00000:00000"},
+		{4, true, false, "00059:00000:00000:00000:00000"},
+		{4, false, true, "00000:00000:00000:00000:95000"},
+		{4, true, true, "00000:00000:00000:00000:00059"},
+	}
+	for _, c := range cases {
+		d, _ := newTestDisplay(t)
+		d.Show(img)
+		d.Rotate(c.numCCW)
+		d.Mirror(c.mirrorH, c.mirrorV)
+		if got := shown(d).String(); got != c.want {
+			t.Errorf("Rotate(%d), Mirror(%v, %v): %s, want %s", c.numCCW, c.mirrorH, c.mirrorV, got, c.want)
+		}
+		if want := (c.numCCW%4 + 4) % 4; d.Rotation() != want {
+			t.Errorf("Rotate(%d): Rotation() = %d, want %d", c.numCCW, d.Rotation(), want)
+		}
+	}
+}
+
+func TestOrientRotation(t *testing.T) {
+	cases := []struct {
+		name     string
+		x, y, z  int32
+		rotation int32
+		ok       bool
+	}{
+		{"upright", 0, 1000, 0, 0, true},
+		{"upside down", 0, -1000, 0, 2, true},
+		{"right edge down", 1000, 0, 0, 1, true},
+		{"left edge down", -1000, 0, 0, 3, true},
+		{"tilted upright", 400, 1000, 100, 0, true},
+		{"tilted right edge down", 1000, -450, 300, 1, true},
+		{"diagonal", 700, 700, 0, 0, false},
+		{"flat", 0, 0, 1000, 0, false},
+		{"nearly flat", 0, 500, 800, 0, false},
+		{"still", 0, 0, 0, 0, false},
+	}
+	for _, c := range cases {
+		rotation, ok := orientRotation(c.x, c.y, c.z)
+		if ok != c.ok || (ok && rotation != c.rotation) {
+			t.Errorf("%s: %d, %v, want %d, %v", c.name, rotation, ok, c.rotation, c.ok)
+		}
+	}
+}
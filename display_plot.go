@@ -0,0 +1,126 @@
+package ubit
+
+import (
+	"github.com/wencode/ubit/common"
+)
+
+type plotState struct {
+	// highest value seen by PlotBarGraph, used when called without a high
+	barHigh int
+}
+
+// Plot lights the pixel at x, y at full brightness.
+func (d *ModDisplay) Plot(x, y int16) {
+	d.SetBrightness(x, y, 255)
+}
+
+func (d *ModDisplay) Unplot(x, y int16) {
+	d.SetBrightness(x, y, 0)
+}
+
+// Toggle switches the pixel at x, y on if it is off, and off otherwise.
+func (d *ModDisplay) Toggle(x, y int16) {
+	if d.Point(x, y) {
+		d.Unplot(x, y)
+	} else {
+		d.Plot(x, y)
+	}
+}
+
+// Point reports whether the pixel at x, y is on.
+func (d *ModDisplay) Point(x, y int16) bool {
+	return d.GetBrightness(x, y) != 0
+}
+
+// PlotBarGraph lights a bar growing from the bottom center of the display
+// as value goes from 0 to high, like MakeCode's led.plotBarGraph. The sign
+// of value is ignored. With high <= 0 the graph scales to the highest value
+// seen so far.
+func (d *ModDisplay) PlotBarGraph(value, high int) {
+	if value < 0 {
+		value = -value
+	}
+	d.lock()
+	defer d.unlock()
+	if high <= 0 {
+		if value > d.plot.barHigh {
+			d.plot.barHigh = value
+		}
+		high = d.plot.barHigh
+		if high == 0 {
+			high = 1
+		}
+	}
+
+	// 15 steps, three pixels on each side of the center column per row
+	v := value * 15 / high
+	k := 0
+	d.begin()
+	defer d.end()
+	for y := int16(display_height - 1); y >= 0; y-- {
+		for x := int16(0); x < 3; x++ {
+			var lit uint8
+			if k <= v {
+				lit = 255
+			}
+			d.setBrightness(2-x, y, lit)
+			d.setBrightness(2+x, y, lit)
+			k++
+		}
+	}
+}
+
+// PlotLine moves the display one column to the left and plots value as a
+// single pixel in the new right column, low at the bottom and high at the
+// top.
+func (d *ModDisplay) PlotLine(value, low, high int) error {
+	if high <= low {
+		return common.ErrInvalidArgument
+	}
+	level := plotScale(value, low, high, display_height-1)
+	d.plotPush(func(y int) bool {
+		return y == display_height-1-level
+	})
+	return nil
+}
+
+// PlotColumn is like PlotLine but fills the new column from the bottom up
+// to value.
+func (d *ModDisplay) PlotColumn(value, low, high int) error {
+	if high <= low {
+		return common.ErrInvalidArgument
+	}
+	level := plotScale(value, low, high, display_height)
+	d.plotPush(func(y int) bool {
+		return y >= display_height-level
+	})
+	return nil
+}
+
+// plotPush moves every column to the left and fills the right column with
+// the rows lit returns true for.
+func (d *ModDisplay) plotPush(lit func(y int) bool) {
+	d.lock()
+	defer d.unlock()
+	d.begin()
+	defer d.end()
+	for y := 0; y < display_height; y++ {
+		row := d.buffer[y*display_width : (y+1)*display_width]
+		copy(row, row[1:])
+		row[display_width-1] = 0
+		if lit(y) {
+			row[display_width-1] = 255
+		}
+	}
+}
+
+// plotScale maps value from low..high to 0..steps, rounded to the nearest.
+func plotScale(value, low, high, steps int) int {
+	if value <= low {
+		return 0
+	}
+	if value >= high {
+		return steps
+	}
+	return ((value-low)*steps*2 + (high - low)) / ((high - low) * 2)
+}
@@ -0,0 +1,63 @@
+package ubit
+
+import (
+	"testing"
+
+	"github.com/wencode/ubit/common"
+	"github.com/wencode/ubit/image5x5"
+)
+
+func TestPlotBarGraph(t *testing.T) {
+	cases := []struct {
+		name  string
+		plots [][2]int // value and high of each call
+		want  string
+	}{
+		{"zero", [][2]int{{0, 10}}, "00000:00000:00000:00000:00900"},
+		{"partial row", [][2]int{{4, 15}}, "00000:00000:00000:09990:99999"},
+		{"full rows", [][2]int{{5, 15}}, "00000:00000:00000:99999:99999"},
+		{"negative", [][2]int{{-5, 15}}, "00000:00000:00000:99999:99999"},
+		{"full", [][2]int{{15, 15}}, "99999:99999:99999:99999:99999"},
+		{"above high", [][2]int{{30, 15}}, "99999:99999:99999:99999:99999"},
+		{"auto high", [][2]int{{7, 0}}, "99999:99999:99999:99999:99999"},
+		{"auto high kept", [][2]int{{10, 0}, {5, 0}}, "00000:00000:09990:99999:99999"},
+		{"auto zero", [][2]int{{0, 0}}, "00000:00000:00000:00000:00900"},
+	}
+	for _, c := range cases {
+		d, _ := newTestDisplay(t)
+		for _, p := range c.plots {
+			d.PlotBarGraph(p[0], p[1])
+		}
+		if got := image5x5.Image(d.buffer).String(); got != c.want {
+			t.Errorf("%s: %s, want %s", c.name, got, c.want)
+		}
+	}
+}
+
+func TestPlotPush(t *testing.T) {
+	cases := []struct {
+		name   string
+		plot   func(d *ModDisplay, value, low, high int) error
+		values []int
+		want   string
+	}{
+		{"line", (*ModDisplay).PlotLine, []int{0, 1, 2, 3, 4}, "00009:00090:00900:09000:90000"},
+		{"line clamped", (*ModDisplay).PlotLine, []int{-3, 9}, "00009:00000:00000:00000:00090"},
+		{"column", (*ModDisplay).PlotColumn, []int{0, 1, 2, 3, 4}, "00009:00099:00999:00999:09999"},
+		{"column scrolled out", (*ModDisplay).PlotColumn, []int{4, 0, 0, 0, 0, 0}, "00000:00000:00000:00000:00000"},
+	}
+	for _, c := range cases {
+		d, _ := newTestDisplay(t)
+		for _, v := range c.values {
+			if err := c.plot(d, v, 0, 4); err != nil {
+				t.Fatalf("%s: %v", c.name, err)
+			}
+		}
+		if got := image5x5.Image(d.buffer).String(); got != c.want {
+			t.Errorf("%s: %s, want %s", c.name, got, c.want)
+		}
+		if err := c.plot(d, 1, 4, 4); err != common.ErrInvalidArgument {
+			t.Errorf("%s: error %v with low == high", c.name, err)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package ubit
+
+import (
+	"time"
+)
+
+// The matrix is scanned by a timer counting microseconds, TIMER1 on the
+// micro:bit. Channel 0 ends a row period, channel 1 is moved along the row
+// to switch columns off once their brightness time has elapsed.
+const (
+	// row period in timer ticks, a full frame takes display_height rows
+	scan_row_ticks = uint32(row_period / time.Microsecond)
+	// columns are never switched off earlier than this, a shorter on-time
+	// could be missed while the interrupt for the row start is still running
+	scan_min_ticks = 30
+	scan_no_ticks  = 0xFFFFFFFF
+)
+
+// gammaTable maps a brightness to the on-time of a led, following a gamma
+// of 2.2 so that equal steps of brightness look like equal steps of light.
+var gammaTable = [256]uint8{
+	0, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+	1, 1, 1, 1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2,
+	3, 3, 3, 3, 3, 4, 4, 4, 4, 5, 5, 5, 5, 6, 6, 6,
+	6, 7, 7, 7, 8, 8, 8, 9, 9, 9, 10, 10, 11, 11, 11, 12,
+	12, 13, 13, 13, 14, 14, 15, 15, 16, 16, 17, 17, 18, 18, 19, 19,
+	20, 20, 21, 22, 22, 23, 23, 24, 25, 25, 26, 26, 27, 28, 28, 29,
+	30, 30, 31, 32, 33, 33, 34, 35, 35, 36, 37, 38, 39, 39, 40, 41,
+	42, 43, 43, 44, 45, 46, 47, 48, 49, 49, 50, 51, 52, 53, 54, 55,
+	56, 57, 58, 59, 60, 61, 62, 63, 64, 65, 66, 67, 68, 69, 70, 71,
+	73, 74, 75, 76, 77, 78, 79, 81, 82, 83, 84, 85, 87, 88, 89, 90,
+	91, 93, 94, 95, 97, 98, 99, 100, 102, 103, 105, 106, 107, 109, 110, 111,
+	113, 114, 116, 117, 119, 120, 121, 123, 124, 126, 127, 129, 130, 132, 133, 135,
+	137, 138, 140, 141, 143, 145, 146, 148, 149, 151, 153, 154, 156, 158, 159, 161,
+	163, 165, 166, 168, 170, 172, 173, 175, 177, 179, 181, 182, 184, 186, 188, 190,
+	192, 194, 196, 197, 199, 201, 203, 205, 207, 209, 211, 213, 215, 217, 219, 221,
+	223, 225, 227, 229, 231, 234, 236, 238, 240, 242, 244, 246, 248, 251, 253, 255,
+}
+
+type scanState struct {
+	row     int
+	offTick [display_width]uint32
+	// tick channel 1 of the timer is armed at
+	next uint32
+}
+
+func (d *ModDisplay) scanStart() {
+	d.scan.row = display_height - 1
+	d.scanSetOff(scan_no_ticks)
+	d.timer.Start(scan_row_ticks, d.scanHandler)
+}
+
+func (d *ModDisplay) scanStop() {
+	d.timer.Stop()
+	d.blank()
+}
+
+func (d *ModDisplay) scanHandler(channel int) {
+	d.lock()
+	switch {
+	case channel == 0:
+		d.scanRow()
+	case d.scan.row == display_height:
+		d.senseStep()
+	default:
+		d.scanColumnsOff(d.scan.next)
+	}
+	d.unlock()
+}
+
+// scanRow moves on to the next row and switches on every lit column of it.
+// A pixel of value 255 stays on for the whole row period and lower values
+// for a proportional slice of it. While light readings are being taken,
+// every fourth frame ends with one more row period to measure the ambient
+// light, see display_light.go.
+func (d *ModDisplay) scanRow() {
+	d.blank()
+
+	rows := display_height
+	if d.senseDue() {
+		rows++
+	}
+	y := d.scan.row + 1
+	if y >= rows {
+		y = 0
+	}
+	d.scan.row = y
+	if y == 0 {
+		d.swap()
+		d.senseFrame()
+	}
+	if y == display_height {
+		d.senseStart()
+		return
+	}
+
+	for x := 0; x < display_width; x++ {
+		value := uint32(d.front[d.bufferIndex(x, y)])
+		if value != 0 {
+			// round up, a lit pixel is not switched off by dimming
+			value = (value*uint32(d.level) + 254) / 255
+		}
+		if d.gamma {
+			value = uint32(gammaTable[value])
+		}
+		off := scan_row_ticks * value / 255
+		if value != 0 && off < scan_min_ticks {
+			off = scan_min_ticks
+		}
+		// an off event this close to the row end could still be pending
+		// at the next row start and switch that row's columns off, keep
+		// the column on for the whole row instead
+		if off > scan_row_ticks-scan_min_ticks {
+			off = scan_row_ticks
+		}
+		d.scan.offTick[x] = off
+	}
+
+	d.rowPins[y].High()
+	for x := 0; x < display_width; x++ {
+		if d.scan.offTick[x] > 0 {
+			d.colPins[x].Low()
+		}
+	}
+	d.scanNextOff()
+}
+
+// scanColumnsOff switches off the columns whose on-time ends at tick.
+func (d *ModDisplay) scanColumnsOff(tick uint32) {
+	for x := 0; x < display_width; x++ {
+		if off := d.scan.offTick[x]; off > 0 && off <= tick {
+			d.colPins[x].High()
+			d.scan.offTick[x] = 0
+		}
+	}
+	d.scanNextOff()
+}
+
+// scanNextOff arms channel 1 for the next column to switch off within the row.
+func (d *ModDisplay) scanNextOff() {
+	next := uint32(scan_no_ticks)
+	for x := 0; x < display_width; x++ {
+		if off := d.scan.offTick[x]; off > 0 && off < scan_row_ticks && off < next {
+			next = off
+		}
+	}
+	d.scanSetOff(next)
+}
+
+func (d *ModDisplay) scanSetOff(tick uint32) {
+	d.scan.next = tick
+	d.timer.SetCompare(1, tick)
+}
@@ -0,0 +1,217 @@
+package ubit
+
+import (
+	"github.com/wencode/ubit/font5x5"
+	"github.com/wencode/ubit/image5x5"
+)
+
+const (
+	// milliseconds an image or bitmap scroll stays on each column
+	scroll_interval = 1000
+	// milliseconds a text scroll stays on each column, same as the micro:bit DAL
+	scroll_text_interval = 120
+	// blank columns between two characters of scrolled text
+	scroll_char_gap = 1
+)
+
+// scrollState moves a viewport over a bitmap. pos is the bitmap column (or
+// row, when vertical) shown at the left (top) edge of the display, it runs
+// from first to last by step.
+type scrollState struct {
+	bitmap   *image5x5.Bitmap
+	vertical bool
+	pos      int
+	first    int
+	last     int
+	step     int
+	repeat   int
+}
+
+// Scroll shows img and moves it out of the display one column at a time.
+func (d *ModDisplay) Scroll(img image5x5.Image, opts ...AnimOption) {
+	d.ScrollAsync(img, opts...)
+}
+
+// ScrollWait is like Scroll but returns only after the scroll has finished.
+func (d *ModDisplay) ScrollWait(img image5x5.Image, opts ...AnimOption) {
+	<-d.ScrollAsync(img, opts...)
+}
+
+// ScrollAsync is like Scroll and returns a channel that is closed once the
+// scroll has finished or has been stopped.
+func (d *ModDisplay) ScrollAsync(img image5x5.Image, opts ...AnimOption) <-chan struct{} {
+	cfg := anim_defaultConfig(scroll_interval)
+	cfg.lead = 0
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return d.startScroll(image5x5.BitmapOf(img), &cfg)
+}
+
+// ScrollBitmap moves the display as a viewport across b, one column (or row
+// when scrolling up and down) at a time.
+func (d *ModDisplay) ScrollBitmap(b *image5x5.Bitmap, opts ...AnimOption) {
+	d.ScrollBitmapAsync(b, opts...)
+}
+
+// ScrollBitmapWait is like ScrollBitmap but returns only after the scroll
+// has finished.
+func (d *ModDisplay) ScrollBitmapWait(b *image5x5.Bitmap, opts ...AnimOption) {
+	<-d.ScrollBitmapAsync(b, opts...)
+}
+
+// ScrollBitmapAsync is like ScrollBitmap and returns a channel that is
+// closed once the scroll has finished or has been stopped.
+func (d *ModDisplay) ScrollBitmapAsync(b *image5x5.Bitmap, opts ...AnimOption) <-chan struct{} {
+	cfg := anim_defaultConfig(scroll_interval)
+	cfg.lead = 0
+	cfg.trail = 0
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return d.startScroll(b, &cfg)
+}
+
+// ScrollText moves text, read as UTF-8, across the display one column at a
+// time. Scrolling left or right, characters take the width of their glyph
+// plus a blank column, scrolling up or down the GlyphHeight of the font
+// plus a blank row. Glyphs come from the font set with SetFont or WithFont,
+// characters without one show as '?'.
+func (d *ModDisplay) ScrollText(text string, opts ...AnimOption) {
+	d.ScrollTextAsync(text, opts...)
+}
+
+// ScrollTextWait is like ScrollText but returns only after the text has
+// scrolled past. With WithRepeat(RepeatForever) it never returns.
+func (d *ModDisplay) ScrollTextWait(text string, opts ...AnimOption) {
+	<-d.ScrollTextAsync(text, opts...)
+}
+
+// ScrollTextAsync is like ScrollText and returns a channel that is closed
+// once the text has scrolled past or the scroll has been stopped.
+func (d *ModDisplay) ScrollTextAsync(text string, opts ...AnimOption) <-chan struct{} {
+	cfg := anim_defaultConfig(scroll_text_interval)
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	font := cfg.font
+	if font == nil {
+		d.lock()
+		font = d.font
+		d.unlock()
+	}
+	runes := []rune(text)
+	if cfg.direction == DirUp || cfg.direction == DirDown {
+		glyphHeight := font.GlyphHeight()
+		if glyphHeight < 1 || glyphHeight > display_height {
+			glyphHeight = display_height
+		}
+		height := len(runes)*(glyphHeight+scroll_char_gap) - scroll_char_gap
+		if height < 0 {
+			height = 0
+		}
+		strip := image5x5.NewBitmap(display_width, height)
+		for i, r := range runes {
+			glyph := image5x5.BitmapOf(glyphImage(font, r))
+			strip.Paste(glyph.Crop(0, 0, display_width, glyphHeight), 0, i*(glyphHeight+scroll_char_gap))
+		}
+		return d.startScroll(strip, &cfg)
+	}
+
+	width := 0
+	for i, r := range runes {
+		if i > 0 {
+			width += scroll_char_gap
+		}
+		_, w := font5x5.Span(font, r)
+		width += w
+	}
+
+	strip := image5x5.NewBitmap(width, display_height)
+	x0 := 0
+	for i, r := range runes {
+		if i > 0 {
+			x0 += scroll_char_gap
+		}
+		first, w := font5x5.Span(font, r)
+		glyph := image5x5.BitmapOf(glyphImage(font, r))
+		strip.Paste(glyph.Crop(first, 0, w, display_height), x0, 0)
+		x0 += w
+	}
+	return d.startScroll(strip, &cfg)
+}
+
+func (d *ModDisplay) startScroll(b *image5x5.Bitmap, cfg *AnimConfig) <-chan struct{} {
+	d.lock()
+	defer d.unlock()
+	done := d.animStart(animTypeScroll, cfg.delay)
+
+	s := &d.scroll
+	s.bitmap = b
+	s.vertical = cfg.direction == DirUp || cfg.direction == DirDown
+
+	length, size := b.Width, display_width
+	if s.vertical {
+		length, size = b.Height, display_height
+	}
+	lead, trail := cfg.lead, cfg.trail
+	if lead < 0 {
+		lead = size
+	}
+	if trail < 0 {
+		trail = size
+	}
+
+	if cfg.direction == DirLeft || cfg.direction == DirUp {
+		s.first = -lead
+		s.last = length + trail - size
+		s.step = 1
+		if s.last < s.first {
+			s.last = s.first
+		}
+	} else {
+		s.first = length + lead - size
+		s.last = -trail
+		s.step = -1
+		if s.last > s.first {
+			s.last = s.first
+		}
+	}
+	s.pos = s.first
+	s.repeat = cfg.repeat
+	if s.repeat == 0 {
+		s.repeat = 1
+	}
+
+	d.scrollRender()
+	return done
+}
+
+func (d *ModDisplay) scrollUpdate() {
+	s := &d.scroll
+	if s.pos == s.last {
+		if s.repeat > 0 {
+			s.repeat--
+		}
+		if s.repeat == 0 {
+			d.animEnd()
+			return
+		}
+		s.pos = s.first
+	} else {
+		s.pos += s.step
+	}
+	d.scrollRender()
+}
+
+// scrollRender shows the part of the bitmap under the viewport.
+func (d *ModDisplay) scrollRender() {
+	s := &d.scroll
+	if s.vertical {
+		d.show(s.bitmap.Image(0, s.pos))
+	} else {
+		d.show(s.bitmap.Image(s.pos, 0))
+	}
+}
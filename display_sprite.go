@@ -0,0 +1,217 @@
+package ubit
+
+import (
+	"github.com/wencode/ubit/image5x5"
+)
+
+// Sprite is an image drawn over the frame buffer at a position of the
+// display. Sprites are composited by z-order each time the frame changes,
+// higher z on top, pixels of value 0 are transparent. Everything drawn with
+// Show, SetBrightness and the animations is the background below them.
+type Sprite struct {
+	d          *ModDisplay
+	img        *image5x5.Bitmap
+	x, y       int
+	z          int
+	visible    bool
+	brightness uint8
+}
+
+// NewSprite adds a visible sprite showing img with its top left corner at
+// x, y, above the sprites added before it with the same z.
+func (d *ModDisplay) NewSprite(img *image5x5.Bitmap, x, y int) *Sprite {
+	s := &Sprite{
+		d:          d,
+		img:        img,
+		x:          x,
+		y:          y,
+		visible:    true,
+		brightness: 255,
+	}
+	d.lock()
+	d.begin()
+	d.sprites = append(d.sprites, s)
+	d.spriteSort()
+	d.end()
+	d.unlock()
+	return s
+}
+
+func (d *ModDisplay) RemoveSprite(s *Sprite) {
+	d.lock()
+	d.begin()
+	for i, o := range d.sprites {
+		if o == s {
+			d.sprites = append(d.sprites[:i], d.sprites[i+1:]...)
+			break
+		}
+	}
+	d.end()
+	d.unlock()
+}
+
+// spriteSort keeps the sprites ordered by z, stable for equal z.
+func (d *ModDisplay) spriteSort() {
+	for i := 1; i < len(d.sprites); i++ {
+		for j := i; j > 0 && d.sprites[j-1].z > d.sprites[j].z; j-- {
+			d.sprites[j-1], d.sprites[j] = d.sprites[j], d.sprites[j-1]
+		}
+	}
+}
+
+// composite draws the visible sprites into frame, it runs in the scan
+// interrupt on the swap of the frame buffers.
+func (d *ModDisplay) composite(frame *[display_width * display_height]uint8) {
+	for _, s := range d.sprites {
+		if !s.visible {
+			continue
+		}
+		for y := 0; y < display_height; y++ {
+			for x := 0; x < display_width; x++ {
+				if v := s.img.At(x-s.x, y-s.y); v != 0 {
+					frame[y*display_width+x] = uint8(uint16(v) * uint16(s.brightness) / 255)
+				}
+			}
+		}
+	}
+}
+
+func (s *Sprite) X() int {
+	s.d.lock()
+	defer s.d.unlock()
+	return s.x
+}
+
+func (s *Sprite) Y() int {
+	s.d.lock()
+	defer s.d.unlock()
+	return s.y
+}
+
+func (s *Sprite) Z() int {
+	s.d.lock()
+	defer s.d.unlock()
+	return s.z
+}
+
+func (s *Sprite) MoveTo(x, y int) {
+	s.d.lock()
+	s.d.begin()
+	s.x, s.y = x, y
+	s.d.end()
+	s.d.unlock()
+}
+
+// Move moves the sprite by dx, dy, see CanMove to check for collisions
+// first.
+func (s *Sprite) Move(dx, dy int) {
+	s.d.lock()
+	s.d.begin()
+	s.x += dx
+	s.y += dy
+	s.d.end()
+	s.d.unlock()
+}
+
+// CanMove reports whether moving by dx, dy keeps the lit pixels of the
+// sprite on the display and away from every other visible sprite. The
+// sprite itself does not move.
+func (s *Sprite) CanMove(dx, dy int) bool {
+	s.d.lock()
+	defer s.d.unlock()
+	off := s.litWhere(dx, dy, func(x, y int) bool {
+		return !inDisplay(int16(x), int16(y))
+	})
+	if off {
+		return false
+	}
+	for _, o := range s.d.sprites {
+		if o != s && s.touching(o, dx, dy) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsTouching reports whether both sprites are visible and have a lit pixel
+// at the same place.
+func (s *Sprite) IsTouching(o *Sprite) bool {
+	s.d.lock()
+	defer s.d.unlock()
+	return s.touching(o, 0, 0)
+}
+
+// touching is IsTouching with s moved by dx, dy.
+func (s *Sprite) touching(o *Sprite, dx, dy int) bool {
+	if !s.visible || !o.visible {
+		return false
+	}
+	return s.litWhere(dx, dy, func(x, y int) bool {
+		return o.img.At(x-o.x, y-o.y) != 0
+	})
+}
+
+// IsTouchingEdge reports whether a lit pixel of the sprite is on the border
+// of the display.
+func (s *Sprite) IsTouchingEdge() bool {
+	s.d.lock()
+	defer s.d.unlock()
+	return s.litWhere(0, 0, func(x, y int) bool {
+		return x == 0 || x == display_width-1 || y == 0 || y == display_height-1
+	})
+}
+
+// litWhere reports whether f is true at the display position of any lit
+// pixel of the sprite moved by dx, dy.
+func (s *Sprite) litWhere(dx, dy int, f func(x, y int) bool) bool {
+	for y := 0; y < s.img.Height; y++ {
+		for x := 0; x < s.img.Width; x++ {
+			if s.img.Pix[y*s.img.Width+x] != 0 && f(s.x+dx+x, s.y+dy+y) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (s *Sprite) SetImage(img *image5x5.Bitmap) {
+	s.d.lock()
+	s.d.begin()
+	s.img = img
+	s.d.end()
+	s.d.unlock()
+}
+
+// SetZ moves the sprite above the sprites of lower z and below those of
+// higher z.
+func (s *Sprite) SetZ(z int) {
+	s.d.lock()
+	s.d.begin()
+	s.z = z
+	s.d.spriteSort()
+	s.d.end()
+	s.d.unlock()
+}
+
+func (s *Sprite) SetVisible(visible bool) {
+	s.d.lock()
+	s.d.begin()
+	s.visible = visible
+	s.d.end()
+	s.d.unlock()
+}
+
+func (s *Sprite) IsVisible() bool {
+	s.d.lock()
+	defer s.d.unlock()
+	return s.visible
+}
+
+// SetBrightness scales the pixels of the sprite by brightness/255.
+func (s *Sprite) SetBrightness(brightness uint8) {
+	s.d.lock()
+	s.d.begin()
+	s.brightness = brightness
+	s.d.end()
+	s.d.unlock()
+}
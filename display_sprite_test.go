@@ -0,0 +1,112 @@
+package ubit
+
+import (
+	"testing"
+
+	"github.com/wencode/ubit/image5x5"
+)
+
+// dot returns a 1x1 bitmap lit at value.
+func dot(value uint8) *image5x5.Bitmap {
+	b := image5x5.NewBitmap(1, 1)
+	b.Set(0, 0, value)
+	return b
+}
+
+func TestSpriteCanMove(t *testing.T) {
+	d, _ := newTestDisplay(t)
+	s := d.NewSprite(dot(255), 2, 2)
+	d.NewSprite(dot(255), 3, 2)
+	hidden := d.NewSprite(dot(255), 2, 1)
+	hidden.SetVisible(false)
+
+	cases := []struct {
+		dx, dy int
+		want   bool
+	}{
+		{0, 0, true},
+		{1, 0, false}, // onto the other sprite
+		{-1, 0, true},
+		{0, -1, true},  // onto the hidden sprite
+		{-2, -2, true}, // to the corner
+		{-3, 0, false}, // off the left edge
+		{0, 3, false},  // off the bottom edge
+	}
+	for _, c := range cases {
+		if got := s.CanMove(c.dx, c.dy); got != c.want {
+			t.Errorf("CanMove(%d, %d) = %v, want %v", c.dx, c.dy, got, c.want)
+		}
+		if s.X() != 2 || s.Y() != 2 {
+			t.Fatalf("CanMove(%d, %d) moved the sprite to %d, %d", c.dx, c.dy, s.X(), s.Y())
+		}
+	}
+}
+
+func TestSpriteIsTouching(t *testing.T) {
+	d, _ := newTestDisplay(t)
+	bar := image5x5.NewBitmap(3, 1)
+	bar.Set(0, 0, 255)
+	bar.Set(2, 0, 255)
+
+	cases := []struct {
+		name         string
+		x, y         int
+		visible      bool
+		touching     bool
+		touchingEdge bool
+	}{
+		{"on a lit pixel", 2, 1, true, true, false},
+		{"on the gap", 1, 1, true, false, false},
+		{"row below", 2, 2, true, false, false},
+		{"hidden", 2, 1, false, false, false},
+		{"on the edge", 4, 0, true, false, true},
+	}
+	for _, c := range cases {
+		s := d.NewSprite(bar, 0, 1)
+		o := d.NewSprite(dot(255), c.x, c.y)
+		o.SetVisible(c.visible)
+		if got := s.IsTouching(o); got != c.touching {
+			t.Errorf("%s: IsTouching = %v, want %v", c.name, got, c.touching)
+		}
+		if got := o.IsTouching(s); got != c.touching {
+			t.Errorf("%s: IsTouching reversed = %v, want %v", c.name, got, c.touching)
+		}
+		if got := o.IsTouchingEdge(); got != c.touchingEdge {
+			t.Errorf("%s: IsTouchingEdge = %v, want %v", c.name, got, c.touchingEdge)
+		}
+		d.RemoveSprite(s)
+		d.RemoveSprite(o)
+	}
+}
+
+func TestSpriteComposite(t *testing.T) {
+	cases := []struct {
+		name   string
+		za, zb int
+		hideA  bool
+		hideB  bool
+		want   uint8
+	}{
+		{"added last on top", 0, 0, false, false, 20},
+		{"higher z on top", 1, 0, false, false, 10},
+		{"lower z below", 0, -1, false, false, 10},
+		{"hidden top", 0, 0, false, true, 10},
+		{"both hidden", 0, 0, true, true, 5},
+	}
+	for _, c := range cases {
+		d, _ := newTestDisplay(t)
+		a := d.NewSprite(dot(10), 1, 1)
+		b := d.NewSprite(dot(20), 1, 1)
+		a.SetZ(c.za)
+		b.SetZ(c.zb)
+		a.SetVisible(!c.hideA)
+		b.SetVisible(!c.hideB)
+
+		var frame [display_width * display_height]uint8
+		frame[display_width+1] = 5
+		d.composite(&frame)
+		if got := frame[display_width+1]; got != c.want {
+			t.Errorf("%s: pixel %d, want %d", c.name, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,296 @@
+package ubit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wencode/ubit/font5x5"
+	"github.com/wencode/ubit/hal"
+	"github.com/wencode/ubit/image5x5"
+)
+
+func newTestDisplay(t *testing.T) (*ModDisplay, *hal.FakeTimer) {
+	d := NewModDisplay()
+	for i := 0; i < 5; i++ {
+		d.rowPins[i] = &hal.FakePin{}
+		d.colPins[i] = &hal.FakePin{}
+	}
+	timer := &hal.FakeTimer{}
+	d.timer = timer
+	d.On()
+	t.Cleanup(d.Off)
+	return d, timer
+}
+
+// lit returns the columns driving a led of the selected row, - where none
+// is selected.
+func lit(d *ModDisplay) string {
+	s := ""
+	for y := 0; y < display_height; y++ {
+		if !d.rowPins[y].Get() {
+			continue
+		}
+		for x := 0; x < display_width; x++ {
+			if d.colPins[x].Get() {
+				s += "."
+			} else {
+				s += "#"
+			}
+		}
+	}
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+func TestDisplayScan(t *testing.T) {
+	d, timer := newTestDisplay(t)
+	if !timer.Running || timer.Period != scan_row_ticks {
+		t.Fatalf("timer running %v period %d", timer.Running, timer.Period)
+	}
+	d.SetBrightness(1, 0, 255)
+	d.SetBrightness(3, 0, 128)
+	d.SetBrightness(2, 1, 255)
+
+	timer.Fire(0)
+	if got := lit(d); got != ".#.#." {
+		t.Errorf("row 0 lit %q", got)
+	}
+	if want := scan_row_ticks * 128 / 255; timer.Compare[1] != want {
+		t.Errorf("off at %d, want %d", timer.Compare[1], want)
+	}
+	timer.Fire(1)
+	if got := lit(d); got != ".#..." {
+		t.Errorf("row 0 lit %q after half brightness", got)
+	}
+	if timer.Compare[1] != scan_no_ticks {
+		t.Errorf("off armed at %d for a full row", timer.Compare[1])
+	}
+
+	timer.Fire(0)
+	if got := lit(d); got != "..#.." {
+		t.Errorf("row 1 lit %q", got)
+	}
+
+	d.Off()
+	if timer.Running || lit(d) != "-" {
+		t.Errorf("display still scanning after Off")
+	}
+}
+
+// A column switched off just before the row ends is kept on for the whole
+// row, so that no late off event reaches the next row.
+func TestDisplayScanNearlyFull(t *testing.T) {
+	d, timer := newTestDisplay(t)
+	d.SetBrightness(0, 0, 254)
+	d.SetBrightness(1, 0, 128)
+	d.SetBrightness(0, 1, 254)
+
+	timer.Fire(0)
+	if want := scan_row_ticks * 128 / 255; timer.Compare[1] != want {
+		t.Fatalf("off at %d, want %d", timer.Compare[1], want)
+	}
+	timer.Fire(1)
+	if got := lit(d); got != "#...." {
+		t.Errorf("row 0 lit %q", got)
+	}
+	if timer.Compare[1] != scan_no_ticks {
+		t.Errorf("off armed at %d for a nearly full row", timer.Compare[1])
+	}
+	timer.Fire(0)
+	if got := lit(d); got != "#...." {
+		t.Errorf("row 1 lit %q", got)
+	}
+}
+
+func TestDisplayScanSwap(t *testing.T) {
+	d, timer := newTestDisplay(t)
+	d.Begin()
+	d.Show(image5x5.Heart)
+	for i := 0; i < display_height; i++ {
+		timer.Fire(0)
+	}
+	if d.front != (image5x5.Image{}) {
+		t.Errorf("open batch shown")
+	}
+	d.Commit()
+	for i := 0; i < display_height; i++ {
+		timer.Fire(0)
+	}
+	if d.front != image5x5.Heart {
+		t.Errorf("committed frame not shown")
+	}
+}
+
+func TestDisplayScrollTiming(t *testing.T) {
+	d, _ := newTestDisplay(t)
+	done := d.ScrollAsync(image5x5.Heart, WithDelay(50))
+	if !d.IsAnimating() {
+		t.Fatal("scroll not started")
+	}
+	steps := 0
+	for d.IsAnimating() {
+		d.animUpdate(49)
+		if d.buffer != image5x5.Heart.Shift(-steps, 0) {
+			t.Fatalf("step %d moved early", steps)
+		}
+		d.animUpdate(1)
+		steps++
+	}
+	select {
+	case <-done:
+	default:
+		t.Error("done not closed")
+	}
+	// one step per column until blank, then one more to end
+	if steps != display_width+1 || d.buffer != (image5x5.Image{}) {
+		t.Errorf("scrolled out in %d steps, want %d", steps, display_width+1)
+	}
+}
+
+func TestDisplayScrollTextUTF8(t *testing.T) {
+	d, _ := newTestDisplay(t)
+	d.ScrollText("Añ")
+	_, a := font5x5.GlyphSpan('A')
+	_, n := font5x5.GlyphSpan('ñ')
+	if want := a + scroll_char_gap + n; d.scroll.bitmap.Width != want {
+		t.Errorf("strip of %d columns, want %d", d.scroll.bitmap.Width, want)
+	}
+	if got := d.scroll.bitmap.Image(a+scroll_char_gap, 0); got != font5x5.GenImage5x5('ñ', 255) {
+		t.Errorf("ñ scrolled as %v", got)
+	}
+}
+
+func TestDisplayScrollTextFont(t *testing.T) {
+	d, _ := newTestDisplay(t)
+	font := &font5x5.TableFont{Width: 5, Height: 5}
+	font.RegisterGlyph('♥', image5x5.Heart)
+	d.ScrollText("♥♥", WithFont(font))
+	if w := d.scroll.bitmap.Width; w != 2*display_width+scroll_char_gap {
+		t.Errorf("strip of %d columns", w)
+	}
+	if got := d.scroll.bitmap.Image(0, 0); got != image5x5.Heart {
+		t.Errorf("scrolled %v", got)
+	}
+
+	d.SetFont(font)
+	d.ShowCharacter('♥')
+	if d.buffer != image5x5.Heart {
+		t.Error("ShowCharacter ignores SetFont")
+	}
+}
+
+func TestDisplayScrollTextUp(t *testing.T) {
+	cases := []struct {
+		height int
+		want   int
+	}{
+		{3, 3},
+		{5, 5},
+		{7, 5}, // cropped to the display
+		{0, 5},
+	}
+	for _, c := range cases {
+		d, _ := newTestDisplay(t)
+		font := &font5x5.TableFont{Width: 5, Height: c.height}
+		font.RegisterGlyph('♥', image5x5.Heart)
+		d.ScrollText("♥♥", WithFont(font), WithDirection(DirUp))
+		if h := d.scroll.bitmap.Height; h != 2*c.want+scroll_char_gap {
+			t.Errorf("glyphs of %d rows: strip of %d rows", c.height, h)
+		}
+		for y := 0; y < display_height; y++ {
+			want := uint8(0)
+			if y < c.want {
+				want = image5x5.Heart[y*display_width]
+			}
+			if got := d.scroll.bitmap.At(0, c.want+scroll_char_gap+y); got != want {
+				t.Errorf("glyphs of %d rows: %d at row %d of the second, want %d", c.height, got, y, want)
+			}
+		}
+	}
+}
+
+// A new animation starts at once, not after the interval of the one it
+// replaces.
+func TestDisplayAnimationRestart(t *testing.T) {
+	d, _ := newTestDisplay(t)
+	go d.bgloop()
+	defer func() {
+		d.quitWg.Add(1)
+		d.quitch <- struct{}{}
+		d.quitWg.Wait()
+	}()
+
+	d.ScrollAsync(image5x5.Heart, WithDelay(3000))
+	time.Sleep(50 * time.Millisecond)
+	start := time.Now()
+	d.ScrollWait(image5x5.Heart, WithDelay(10))
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("short scroll took %v", elapsed)
+	}
+}
+
+func TestDisplayLightSense(t *testing.T) {
+	d, timer := newTestDisplay(t)
+	// frames scans until n more frames have started and returns the number
+	// of sense rows on the way
+	frames := func(n int) int {
+		sensed := 0
+		for n > 0 {
+			timer.Fire(0)
+			switch d.scan.row {
+			case 0:
+				n--
+			case display_height:
+				timer.Fire(1)
+				timer.Fire(1)
+				sensed++
+			}
+		}
+		return sensed
+	}
+	if frames(8) != 0 {
+		t.Fatalf("sense row scanned before the first reading")
+	}
+
+	if got := d.ReadLightLevel(); got != 0 {
+		t.Errorf("first reading %d, want 0", got)
+	}
+	for i := range d.sense.adc {
+		d.sense.adc[i] = &hal.FakeADC{Value: 0}
+	}
+
+	// the leds charge before the sense columns float
+	for d.scan.row != display_height {
+		timer.Fire(0)
+	}
+	pin := d.colPins[senseColumns[0]].(*hal.FakePin)
+	if lit(d) != "-" || !pin.Level || pin.Mode != hal.PinOutput || timer.Compare[1] != scan_charge_ticks {
+		t.Errorf("sense row not charging: mode %v level %v off at %d", pin.Mode, pin.Level, timer.Compare[1])
+	}
+	timer.Fire(1)
+	if pin.Mode != hal.PinRelease || timer.Compare[1] != scan_sense_ticks {
+		t.Errorf("sense column not floating: mode %v off at %d", pin.Mode, timer.Compare[1])
+	}
+	timer.Fire(1)
+	if pin.Mode != hal.PinOutput || !pin.Level || timer.Compare[1] != scan_no_ticks {
+		t.Errorf("sense column not restored: mode %v level %v", pin.Mode, pin.Level)
+	}
+
+	// one frame in sense_frame_period, depending on where counting starts
+	want := 40 / sense_frame_period
+	if n := frames(40); n < want-1 || n > want {
+		t.Errorf("%d sense rows in 40 frames, want %d", n, want)
+	}
+	d.ReadLightLevel()
+	frames(40)
+	if got := d.ReadLightLevel(); got < 250 {
+		t.Errorf("level %d in bright light", got)
+	}
+
+	frames(sense_idle_frames)
+	if n := frames(8); n != 0 {
+		t.Errorf("%d sense rows without readings", n)
+	}
+}
@@ -0,0 +1,163 @@
+package ubit
+
+import (
+	"github.com/wencode/ubit/image5x5"
+)
+
+const (
+	// milliseconds between two steps of a transition
+	transition_interval = 20
+)
+
+// Transition tells how Transition changes from the shown image to the next.
+type Transition int32
+
+const (
+	// TransitionFade fades the current image out and then the next one in
+	TransitionFade Transition = iota
+	// TransitionCrossFade blends the current image into the next one
+	TransitionCrossFade
+	// TransitionWipe uncovers the next image line by line
+	TransitionWipe
+	// TransitionSlide pushes the current image out with the next one
+	TransitionSlide
+)
+
+type transitionState struct {
+	from     [display_width * display_height]uint8
+	to       image5x5.Image
+	kind     Transition
+	dir      Direction
+	elapse   int32
+	duration int32
+}
+
+// Transition changes the display to img over ms milliseconds. Wipe and slide
+// move in the direction of WithDirection, to the left by default.
+func (d *ModDisplay) Transition(img image5x5.Image, kind Transition, ms int, opts ...AnimOption) {
+	d.TransitionAsync(img, kind, ms, opts...)
+}
+
+// TransitionWait is like Transition but returns once img is fully shown.
+func (d *ModDisplay) TransitionWait(img image5x5.Image, kind Transition, ms int, opts ...AnimOption) {
+	<-d.TransitionAsync(img, kind, ms, opts...)
+}
+
+// TransitionAsync is like Transition and returns a channel that is closed
+// once img is fully shown or the transition has been stopped.
+func (d *ModDisplay) TransitionAsync(img image5x5.Image, kind Transition, ms int, opts ...AnimOption) <-chan struct{} {
+	cfg := anim_defaultConfig(transition_interval)
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	d.lock()
+	defer d.unlock()
+	done := d.animStart(animTypeTransition, cfg.delay)
+	t := &d.transition
+	t.from = d.buffer
+	t.to = img
+	t.kind = kind
+	t.dir = cfg.direction
+	t.elapse = 0
+	t.duration = int32(ms)
+	if t.duration <= 0 {
+		d.show(img)
+		d.animEnd()
+	}
+	return done
+}
+
+// FadeOut fades the display to black over ms milliseconds.
+func (d *ModDisplay) FadeOut(ms int) {
+	d.Transition(image5x5.Image{}, TransitionCrossFade, ms)
+}
+
+// FadeIn fades img in from black over ms milliseconds.
+func (d *ModDisplay) FadeIn(img image5x5.Image, ms int) {
+	d.Clear()
+	d.Transition(img, TransitionCrossFade, ms)
+}
+
+// transitionUpdate moves the transition on by the milliseconds elapsed
+// since its previous step, so that a late step does not stretch it.
+func (d *ModDisplay) transitionUpdate(elapse int32) {
+	t := &d.transition
+	t.elapse += elapse
+	if t.elapse >= t.duration {
+		d.show(t.to)
+		d.animEnd()
+		return
+	}
+
+	// progress of the transition in 1/256th
+	p := t.elapse * 256 / t.duration
+	d.begin()
+	defer d.end()
+	for y := 0; y < display_height; y++ {
+		for x := 0; x < display_width; x++ {
+			idx := y*display_width + x
+			from, to := int32(t.from[idx]), int32(t.to[idx])
+			var value int32
+			switch t.kind {
+			case TransitionFade:
+				if p < 128 {
+					value = from * (128 - p) / 128
+				} else {
+					value = to * (p - 128) / 128
+				}
+			case TransitionCrossFade:
+				value = from + (to-from)*p/256
+			case TransitionWipe:
+				value = from
+				if t.wiped(x, y, p) {
+					value = to
+				}
+			case TransitionSlide:
+				value = t.slide(x, y, p)
+			}
+			d.buffer[idx] = uint8(value)
+		}
+	}
+}
+
+// wiped reports whether the wipe has passed over x, y at progress p.
+func (t *transitionState) wiped(x, y int, p int32) bool {
+	lines := int((p*display_width + 255) / 256)
+	switch t.dir {
+	case DirRight:
+		return x < lines
+	case DirUp:
+		return y >= display_height-lines
+	case DirDown:
+		return y < lines
+	}
+	return x >= display_width-lines
+}
+
+// slide returns the pixel at x, y with both images moved by progress p.
+func (t *transitionState) slide(x, y int, p int32) int32 {
+	offset := int(p * display_width / 256)
+	sx, sy := x, y
+	switch t.dir {
+	case DirLeft:
+		sx = x + offset
+	case DirRight:
+		sx = x - offset
+	case DirUp:
+		sy = y + offset
+	case DirDown:
+		sy = y - offset
+	}
+	switch {
+	case sx >= display_width:
+		return int32(t.to[sy*display_width+sx-display_width])
+	case sx < 0:
+		return int32(t.to[sy*display_width+sx+display_width])
+	case sy >= display_height:
+		return int32(t.to[(sy-display_height)*display_width+sx])
+	case sy < 0:
+		return int32(t.to[(sy+display_height)*display_width+sx])
+	}
+	return int32(t.from[sy*display_width+sx])
+}
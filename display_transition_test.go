@@ -0,0 +1,139 @@
+package ubit
+
+import (
+	"testing"
+
+	"github.com/wencode/ubit/image5x5"
+)
+
+// filled returns an image with every pixel at value.
+func filled(value uint8) image5x5.Image {
+	var img image5x5.Image
+	for i := range img {
+		img[i] = value
+	}
+	return img
+}
+
+// transitionAt starts a transition of 256ms from the shown image to to and
+// moves it on by ms, so that its progress is ms/256th.
+func transitionAt(t *testing.T, from, to image5x5.Image, kind Transition, dir Direction, ms int32) *ModDisplay {
+	d, _ := newTestDisplay(t)
+	d.Show(from)
+	d.TransitionAsync(to, kind, 256, WithDirection(dir), WithDelay(int(ms)))
+	d.animUpdate(ms)
+	return d
+}
+
+func TestTransitionKinds(t *testing.T) {
+	cases := []struct {
+		name string
+		kind Transition
+		ms   int32
+		want uint8
+	}{
+		{"fade out", TransitionFade, 64, 100},
+		{"fade black", TransitionFade, 128, 0},
+		{"fade in", TransitionFade, 192, 50},
+		{"cross-fade", TransitionCrossFade, 64, 175},
+		{"cross-fade half", TransitionCrossFade, 128, 150},
+	}
+	for _, c := range cases {
+		d := transitionAt(t, filled(200), filled(100), c.kind, DirLeft, c.ms)
+		if d.buffer != filled(c.want) {
+			t.Errorf("%s: pixels %v, want all %d", c.name, d.buffer[:5], c.want)
+		}
+	}
+}
+
+func TestTransitionWipe(t *testing.T) {
+	cases := []struct {
+		dir  Direction
+		want string
+	}{
+		{DirLeft, "00099:00099:00099:00099:00099"},
+		{DirRight, "99000:99000:99000:99000:99000"},
+		{DirUp, "00000:00000:00000:99999:99999"},
+		{DirDown, "99999:99999:00000:00000:00000"},
+	}
+	for _, c := range cases {
+		// 1.99 of five lines, a line started counts
+		d := transitionAt(t, image5x5.Image{}, filled(255), TransitionWipe, c.dir, 102)
+		if got := image5x5.Image(d.buffer).String(); got != c.want {
+			t.Errorf("wipe %d: %s, want %s", c.dir, got, c.want)
+		}
+	}
+}
+
+func TestTransitionSlide(t *testing.T) {
+	// every pixel distinct, from numbered 1 to 25 and to 101 to 125
+	var from, to image5x5.Image
+	for i := range from {
+		from[i] = uint8(1 + i)
+		to[i] = uint8(101 + i)
+	}
+	// strip returns the pixel at x, y of from and to placed side by side,
+	// or on top of each other when vertical, first being on the left or top
+	strip := func(x, y int, vertical bool, first, second image5x5.Image) uint8 {
+		if vertical {
+			if y >= display_height {
+				return second[(y-display_height)*display_width+x]
+			}
+			return first[y*display_width+x]
+		}
+		if x >= display_width {
+			return second[y*display_width+x-display_width]
+		}
+		return first[y*display_width+x]
+	}
+
+	for offset := 0; offset < display_width; offset++ {
+		ms := int32(offset*256/display_width + 1)
+		for _, dir := range []Direction{DirLeft, DirRight, DirUp, DirDown} {
+			d := transitionAt(t, from, to, TransitionSlide, dir, ms)
+			for y := 0; y < display_height; y++ {
+				for x := 0; x < display_width; x++ {
+					var want uint8
+					switch dir {
+					case DirLeft:
+						want = strip(x+offset, y, false, from, to)
+					case DirRight:
+						want = strip(x+display_width-offset, y, false, to, from)
+					case DirUp:
+						want = strip(x, y+offset, true, from, to)
+					case DirDown:
+						want = strip(x, y+display_height-offset, true, to, from)
+					}
+					if got := d.buffer[y*display_width+x]; got != want {
+						t.Fatalf("slide %d by %d: %d at %d, %d, want %d", dir, offset, got, x, y, want)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestTransitionEnd(t *testing.T) {
+	d, _ := newTestDisplay(t)
+	d.Show(filled(200))
+	done := d.TransitionAsync(image5x5.Heart, TransitionCrossFade, 100, WithDelay(20))
+	// a late step counts the time that has really passed
+	d.animUpdate(60)
+	if want := uint8(200 - 200*153/256); d.buffer[0] != want {
+		t.Errorf("after 60ms of 100: %d, want %d", d.buffer[0], want)
+	}
+	d.animUpdate(40)
+	if d.IsAnimating() || d.buffer != image5x5.Heart {
+		t.Error("transition not ended on time")
+	}
+	select {
+	case <-done:
+	default:
+		t.Error("done not closed")
+	}
+
+	d.TransitionAsync(image5x5.Happy, TransitionWipe, 0)
+	if d.IsAnimating() || d.buffer != image5x5.Happy {
+		t.Error("transition of 0ms not shown at once")
+	}
+}
@@ -11,8 +11,10 @@ func main() {
 	d.Init()
 	defer d.Uninit()
 
-	d.SetBrightness(1, 2, 1)
-	time.Sleep(time.Second * 3)
-	d.SetBrightness(2, 2, 1)
+	for x := int16(0); x < 5; x++ {
+		for y := int16(0); y < 5; y++ {
+			d.SetBrightness(x, y, uint8((y*5+x+1)*255/25))
+		}
+	}
 	time.Sleep(time.Second * 10)
 }
@@ -0,0 +1,18 @@
+package main
+
+import (
+	"github.com/wencode/ubit"
+	"github.com/wencode/ubit/font5x5"
+	"github.com/wencode/ubit/image5x5"
+)
+
+func main() {
+	ubit.Display.Init()
+	defer ubit.Display.Uninit()
+
+	font5x5.RegisterGlyph('♥', image5x5.Heart)
+
+	ubit.Display.ScrollTextWait("Hello")
+	ubit.Display.ScrollTextWait("Temp 21°♥")
+	ubit.Display.ScrollWait(image5x5.Heart)
+}
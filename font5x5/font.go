@@ -10,6 +10,9 @@ const (
 
 	FontWidth  = 5
 	FontHeight = 5
+
+	// SpaceWidth is the number of columns taken by a blank glyph
+	SpaceWidth = 3
 )
 
 var pendolino3 = [475]byte{
@@ -57,18 +60,52 @@ func GetFontData(c byte) []byte {
 	return pendolino3[start : start+siz]
 }
 
-func GenImage5x5(c byte, brightness byte) image5x5.Image {
-	data := GetFontData(c)
-	if data == nil {
-		data = GetFontData(byte('?'))
+// GetGlyph returns the rows of the built-in glyph for r, from pendolino3
+// for ASCII and from the Latin-1 supplement above it. It returns nil if
+// there is no glyph for r.
+func GetGlyph(r rune) []byte {
+	if r >= 0 && r <= AsciiEnd {
+		return GetFontData(byte(r))
+	}
+	return findGlyph(latin1[:], r)
+}
+
+// GenImage5x5 returns the glyph for c in the default font, or '?' if there
+// is none.
+func GenImage5x5(c rune, brightness byte) image5x5.Image {
+	img, ok := Default.Glyph(c)
+	if !ok {
+		img, _ = Default.Glyph('?')
 	}
-	var img image5x5.Image
-	idx := 0
-	for y := 0; y < image5x5.Height; y++ {
-		for x := 0; x < image5x5.Width; x++ {
-			img[idx] = ((data[y] >> (4 - x)) & 1) * brightness
-			idx++
-		}
+	return img.Scale(brightness)
+}
+
+// GlyphSpan returns the first lit column of the glyph for c in the default
+// font and the number of columns from there to its last lit column.
+func GlyphSpan(c rune) (first, width int) {
+	return Span(Default, c)
+}
+
+// digits2x5 packs the digits 0-9 into two columns, for two digits to fit on
+// the display side by side.
+var digits2x5 = [10 * FontHeight]byte{
+	0x3, 0x3, 0x3, 0x3, 0x3, // 0
+	0x1, 0x1, 0x1, 0x1, 0x1, // 1
+	0x3, 0x1, 0x3, 0x2, 0x3, // 2
+	0x3, 0x1, 0x3, 0x1, 0x3, // 3
+	0x2, 0x2, 0x3, 0x1, 0x1, // 4
+	0x3, 0x2, 0x3, 0x1, 0x3, // 5
+	0x2, 0x2, 0x3, 0x3, 0x3, // 6
+	0x3, 0x1, 0x1, 0x1, 0x1, // 7
+	0x3, 0x3, 0x0, 0x3, 0x3, // 8
+	0x3, 0x3, 0x3, 0x1, 0x1, // 9
+}
+
+// GetDigit2x5 returns the rows of digit d in the 2x5 font, bit 1 of each row
+// is the left column. It returns nil if d is not a digit.
+func GetDigit2x5(d int) []byte {
+	if d < 0 || d > 9 {
+		return nil
 	}
-	return img
+	return digits2x5[d*FontHeight : (d+1)*FontHeight]
 }
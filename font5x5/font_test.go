@@ -27,3 +27,101 @@ func TestA(t *testing.T) {
 	img := GenImage5x5('A', 255)
 	display(img)
 }
+
+func TestGlyphSpan(t *testing.T) {
+	cases := []struct {
+		c     rune
+		first int
+		width int
+	}{
+		{'A', 0, 4},
+		{'1', 1, 3},
+		{'i', 1, 1},
+		{'M', 0, 5},
+		{' ', 0, SpaceWidth},
+		{0x7f, 0, 5},
+		{'é', 0, 4},
+		{'ì', 0, 2},
+		{'€', 0, 4},
+	}
+	for _, c := range cases {
+		first, width := GlyphSpan(c.c)
+		if first != c.first || width != c.width {
+			t.Errorf("GlyphSpan(%q) = %d, %d, want %d, %d", c.c, first, width, c.first, c.width)
+		}
+	}
+}
+
+func TestLatin1(t *testing.T) {
+	for i, g := range latin1 {
+		if i > 0 && latin1[i-1].r >= g.r {
+			t.Errorf("%q not sorted after %q", g.r, latin1[i-1].r)
+		}
+		for _, row := range g.rows {
+			if row >= 1<<FontWidth {
+				t.Errorf("%q wider than the font", g.r)
+			}
+		}
+		if GenImage5x5(g.r, 255) == GenImage5x5('?', 255) {
+			t.Errorf("%q shown as '?'", g.r)
+		}
+	}
+	for _, r := range []rune{0x80, 0xa0, 0x100, '♥', -1} {
+		if GetGlyph(r) != nil {
+			t.Errorf("glyph for %q", r)
+		}
+	}
+	if GenImage5x5('ä', 255) == GenImage5x5('a', 255) {
+		t.Error("umlaut missing")
+	}
+}
+
+func TestTableFont(t *testing.T) {
+	// digits 0 and 1 of a 3x5 font
+	f := &TableFont{
+		First:  '0',
+		Last:   '1',
+		Width:  3,
+		Height: 5,
+		Data: []byte{
+			0x7, 0x5, 0x5, 0x5, 0x7,
+			0x2, 0x6, 0x2, 0x2, 0x7,
+		},
+		Widths: []uint8{3, 3},
+	}
+	img, ok := f.Glyph('1')
+	want := image5x5.MustParse("09000:99000:09000:09000:99900")
+	if !ok || img != want {
+		t.Errorf("glyph 1 = %v", img)
+	}
+	if _, ok := f.Glyph('2'); ok {
+		t.Error("glyph beyond Last")
+	}
+	if first, width := Span(f, '1'); first != 0 || width != 3 {
+		t.Errorf("Span(1) = %d, %d", first, width)
+	}
+
+	// a registered glyph replaces the recorded width too
+	f.RegisterGlyph('1', image5x5.MustParse("00900:00900:00900:00900:00900"))
+	if first, width := Span(f, '1'); first != 2 || width != 1 {
+		t.Errorf("Span(1) = %d, %d after RegisterGlyph", first, width)
+	}
+}
+
+func TestRegisterGlyph(t *testing.T) {
+	f := &TableFont{First: AsciiStart, Last: AsciiEnd, Width: FontWidth, Height: FontHeight, Data: pendolino3[:]}
+	f.RegisterGlyph('♥', image5x5.Heart)
+	if img, ok := f.Glyph('♥'); !ok || img != image5x5.Heart {
+		t.Errorf("registered glyph = %v, %v", img, ok)
+	}
+	if first, width := Span(f, '♥'); first != 0 || width != 5 {
+		t.Errorf("Span(♥) = %d, %d", first, width)
+	}
+	f.RegisterGlyph('A', image5x5.Yes)
+	if img, _ := f.Glyph('A'); img != image5x5.Yes {
+		t.Error("A not replaced")
+	}
+	if _, ok := f.Glyph('é'); ok {
+		t.Error("Latin-1 glyph in a font without it")
+	}
+}
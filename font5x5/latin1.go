@@ -0,0 +1,82 @@
+package font5x5
+
+import (
+	"sort"
+)
+
+type glyph struct {
+	r    rune
+	rows [FontHeight]byte
+}
+
+// latin1 holds the letters of Latin-1 used in German, French and Spanish,
+// a few symbols and the euro sign, in the style of pendolino3 and sorted by
+// rune. Accented capitals are squeezed into four rows below the accent.
+var latin1 = [...]glyph{
+	{'¡', [FontHeight]byte{0b01000, 0b00000, 0b01000, 0b01000, 0b01000}},
+	{'£', [FontHeight]byte{0b00110, 0b01000, 0b11100, 0b01000, 0b11110}},
+	{'«', [FontHeight]byte{0b00000, 0b01010, 0b10100, 0b01010, 0b00000}},
+	{'°', [FontHeight]byte{0b01000, 0b10100, 0b01000, 0b00000, 0b00000}},
+	{'µ', [FontHeight]byte{0b00000, 0b10010, 0b10010, 0b11100, 0b10000}},
+	{'·', [FontHeight]byte{0b00000, 0b00000, 0b01000, 0b00000, 0b00000}},
+	{'»', [FontHeight]byte{0b00000, 0b10100, 0b01010, 0b10100, 0b00000}},
+	{'¿', [FontHeight]byte{0b00100, 0b00000, 0b01100, 0b10001, 0b01110}},
+	{'À', [FontHeight]byte{0b01000, 0b01100, 0b10010, 0b11110, 0b10010}},
+	{'Á', [FontHeight]byte{0b00100, 0b01100, 0b10010, 0b11110, 0b10010}},
+	{'Â', [FontHeight]byte{0b01100, 0b01100, 0b10010, 0b11110, 0b10010}},
+	{'Ä', [FontHeight]byte{0b10010, 0b01100, 0b10010, 0b11110, 0b10010}},
+	{'Ç', [FontHeight]byte{0b01110, 0b10000, 0b10000, 0b01110, 0b00100}},
+	{'È', [FontHeight]byte{0b01000, 0b11110, 0b11100, 0b10000, 0b11110}},
+	{'É', [FontHeight]byte{0b00100, 0b11110, 0b11100, 0b10000, 0b11110}},
+	{'Ê', [FontHeight]byte{0b01100, 0b11110, 0b11100, 0b10000, 0b11110}},
+	{'Ë', [FontHeight]byte{0b10010, 0b11110, 0b11100, 0b10000, 0b11110}},
+	{'Í', [FontHeight]byte{0b00100, 0b11100, 0b01000, 0b01000, 0b11100}},
+	{'Î', [FontHeight]byte{0b01000, 0b11100, 0b01000, 0b01000, 0b11100}},
+	{'Ï', [FontHeight]byte{0b10100, 0b11100, 0b01000, 0b01000, 0b11100}},
+	{'Ñ', [FontHeight]byte{0b01010, 0b10001, 0b11001, 0b10101, 0b10011}},
+	{'Ò', [FontHeight]byte{0b01000, 0b01100, 0b10010, 0b10010, 0b01100}},
+	{'Ó', [FontHeight]byte{0b00100, 0b01100, 0b10010, 0b10010, 0b01100}},
+	{'Ô', [FontHeight]byte{0b01100, 0b01100, 0b10010, 0b10010, 0b01100}},
+	{'Ö', [FontHeight]byte{0b10010, 0b01100, 0b10010, 0b10010, 0b01100}},
+	{'×', [FontHeight]byte{0b00000, 0b10100, 0b01000, 0b10100, 0b00000}},
+	{'Ù', [FontHeight]byte{0b01000, 0b10010, 0b10010, 0b10010, 0b01100}},
+	{'Ú', [FontHeight]byte{0b00100, 0b10010, 0b10010, 0b10010, 0b01100}},
+	{'Û', [FontHeight]byte{0b01100, 0b10010, 0b10010, 0b10010, 0b01100}},
+	{'Ü', [FontHeight]byte{0b10010, 0b10010, 0b10010, 0b10010, 0b01100}},
+	{'ß', [FontHeight]byte{0b01100, 0b10010, 0b10100, 0b10010, 0b10100}},
+	{'à', [FontHeight]byte{0b01000, 0b01110, 0b10010, 0b10010, 0b01111}},
+	{'á', [FontHeight]byte{0b00100, 0b01110, 0b10010, 0b10010, 0b01111}},
+	{'â', [FontHeight]byte{0b01100, 0b01110, 0b10010, 0b10010, 0b01111}},
+	{'ä', [FontHeight]byte{0b10010, 0b01110, 0b10010, 0b10010, 0b01111}},
+	{'ç', [FontHeight]byte{0b00000, 0b01110, 0b10000, 0b01110, 0b00100}},
+	{'è', [FontHeight]byte{0b01000, 0b01100, 0b11110, 0b10000, 0b01110}},
+	{'é', [FontHeight]byte{0b00100, 0b01100, 0b11110, 0b10000, 0b01110}},
+	{'ê', [FontHeight]byte{0b01100, 0b01100, 0b11110, 0b10000, 0b01110}},
+	{'ë', [FontHeight]byte{0b10010, 0b01100, 0b11110, 0b10000, 0b01110}},
+	{'ì', [FontHeight]byte{0b10000, 0b00000, 0b01000, 0b01000, 0b01000}},
+	{'í', [FontHeight]byte{0b00100, 0b00000, 0b01000, 0b01000, 0b01000}},
+	{'î', [FontHeight]byte{0b01000, 0b10100, 0b01000, 0b01000, 0b01000}},
+	{'ï', [FontHeight]byte{0b10100, 0b00000, 0b01000, 0b01000, 0b01000}},
+	{'ñ', [FontHeight]byte{0b01010, 0b11100, 0b10010, 0b10010, 0b10010}},
+	{'ò', [FontHeight]byte{0b01000, 0b01100, 0b10010, 0b10010, 0b01100}},
+	{'ó', [FontHeight]byte{0b00100, 0b01100, 0b10010, 0b10010, 0b01100}},
+	{'ô', [FontHeight]byte{0b01100, 0b01100, 0b10010, 0b10010, 0b01100}},
+	{'ö', [FontHeight]byte{0b10010, 0b01100, 0b10010, 0b10010, 0b01100}},
+	{'÷', [FontHeight]byte{0b01000, 0b00000, 0b11100, 0b00000, 0b01000}},
+	{'ù', [FontHeight]byte{0b01000, 0b10010, 0b10010, 0b10010, 0b01111}},
+	{'ú', [FontHeight]byte{0b00100, 0b10010, 0b10010, 0b10010, 0b01111}},
+	{'û', [FontHeight]byte{0b01100, 0b10010, 0b10010, 0b10010, 0b01111}},
+	{'ü', [FontHeight]byte{0b10010, 0b10010, 0b10010, 0b10010, 0b01111}},
+	{'ÿ', [FontHeight]byte{0b10010, 0b10001, 0b01010, 0b00100, 0b11000}},
+	{'€', [FontHeight]byte{0b01110, 0b10000, 0b11100, 0b10000, 0b01110}},
+}
+
+// findGlyph returns the rows of the glyph for r in glyphs sorted by rune,
+// nil if there is none.
+func findGlyph(glyphs []glyph, r rune) []byte {
+	i := sort.Search(len(glyphs), func(i int) bool { return glyphs[i].r >= r })
+	if i == len(glyphs) || glyphs[i].r != r {
+		return nil
+	}
+	return glyphs[i].rows[:]
+}
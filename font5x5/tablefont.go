@@ -0,0 +1,167 @@
+package font5x5
+
+import (
+	"github.com/wencode/ubit/image5x5"
+)
+
+// Font is a set of glyphs that ModDisplay shows and scrolls as text.
+type Font interface {
+	// Glyph returns the glyph for r drawn from the top left corner, and
+	// false if the font has none.
+	Glyph(r rune) (image5x5.Image, bool)
+	// GlyphWidth returns the number of columns the glyph for r takes in
+	// text, from its first lit column.
+	GlyphWidth(r rune) int
+	// GlyphHeight returns the number of rows of the glyphs, which text
+	// scrolled up or down takes for each of them.
+	GlyphHeight() int
+}
+
+// TableFont is a Font of glyphs packed like pendolino3: each row of a glyph
+// takes 1+Width/8 bytes, bit Width-1 being its left column, and the glyphs
+// from First to Last follow each other in Data.
+type TableFont struct {
+	First, Last rune
+	Width       int
+	Height      int
+	Data        []byte
+	// Widths, if set, holds the number of columns each glyph takes in text,
+	// otherwise they are measured from the lit columns.
+	Widths []uint8
+
+	extra      []glyph
+	registered []registeredGlyph
+}
+
+type registeredGlyph struct {
+	r   rune
+	img image5x5.Image
+}
+
+// Default is pendolino3 with the Latin-1 supplement, the font used unless
+// another one is set.
+var Default = &TableFont{
+	First:  AsciiStart,
+	Last:   AsciiEnd,
+	Width:  FontWidth,
+	Height: FontHeight,
+	Data:   pendolino3[:],
+	extra:  latin1[:],
+}
+
+// RegisterGlyph adds img to the default font as the glyph for r, replacing
+// the glyph it had. Icons registered this way can be embedded in text.
+func RegisterGlyph(r rune, img image5x5.Image) {
+	Default.RegisterGlyph(r, img)
+}
+
+// RegisterGlyph adds img as the glyph for r, replacing the glyph f had.
+func (f *TableFont) RegisterGlyph(r rune, img image5x5.Image) {
+	for i := range f.registered {
+		if f.registered[i].r == r {
+			f.registered[i].img = img
+			return
+		}
+	}
+	f.registered = append(f.registered, registeredGlyph{r, img})
+}
+
+func (f *TableFont) Glyph(r rune) (image5x5.Image, bool) {
+	var img image5x5.Image
+	for _, g := range f.registered {
+		if g.r == r {
+			return g.img, true
+		}
+	}
+	rows, stride := f.rows(r)
+	if rows == nil {
+		return img, false
+	}
+	for y := 0; y < image5x5.Height && y < f.Height; y++ {
+		var row uint32
+		for i := 0; i < stride; i++ {
+			row = row<<8 | uint32(rows[y*stride+i])
+		}
+		for x := 0; x < image5x5.Width && x < f.Width; x++ {
+			if (row>>uint(f.Width-1-x))&1 != 0 {
+				img[y*image5x5.Width+x] = 255
+			}
+		}
+	}
+	return img, true
+}
+
+// GlyphWidth returns the width recorded in Widths, unless r has been
+// registered, or else the width of the lit columns of the glyph.
+func (f *TableFont) GlyphWidth(r rune) int {
+	registered := false
+	for _, g := range f.registered {
+		if g.r == r {
+			registered = true
+			break
+		}
+	}
+	if !registered && f.Widths != nil && r >= f.First && r <= f.Last && int(r-f.First) < len(f.Widths) {
+		return int(f.Widths[r-f.First])
+	}
+	img, ok := f.Glyph(r)
+	if !ok {
+		img, _ = f.Glyph('?')
+	}
+	first, last := litColumns(img)
+	if first > last {
+		return SpaceWidth
+	}
+	return last - first + 1
+}
+
+func (f *TableFont) GlyphHeight() int { return f.Height }
+
+// rows returns the packed rows of the glyph for r and the number of bytes
+// of each row.
+func (f *TableFont) rows(r rune) ([]byte, int) {
+	stride := 1 + f.Width/8
+	if r >= f.First && r <= f.Last {
+		siz := stride * f.Height
+		start := int(r-f.First) * siz
+		if start+siz <= len(f.Data) {
+			return f.Data[start : start+siz], stride
+		}
+	}
+	if rows := findGlyph(f.extra, r); rows != nil {
+		return rows, 1
+	}
+	return nil, stride
+}
+
+// Span returns the first lit column of the glyph for r in f, or of '?' if
+// there is none, and the number of columns it takes in text.
+func Span(f Font, r rune) (first, width int) {
+	img, ok := f.Glyph(r)
+	if !ok {
+		img, _ = f.Glyph('?')
+	}
+	first, last := litColumns(img)
+	if first > last {
+		first = 0
+	}
+	return first, f.GlyphWidth(r)
+}
+
+// litColumns returns the first and the last lit column of img, first is
+// greater than last if img is blank.
+func litColumns(img image5x5.Image) (first, last int) {
+	first, last = image5x5.Width, -1
+	for x := 0; x < image5x5.Width; x++ {
+		for y := 0; y < image5x5.Height; y++ {
+			if img[y*image5x5.Width+x] != 0 {
+				if x < first {
+					first = x
+				}
+				last = x
+				break
+			}
+		}
+	}
+	return first, last
+}
@@ -0,0 +1,59 @@
+package hal
+
+// FakePin is a Pin that only records its state.
+type FakePin struct {
+	Mode  PinMode
+	Level bool
+}
+
+func (p *FakePin) Configure(mode PinMode) { p.Mode = mode }
+
+func (p *FakePin) High() { p.Level = true }
+
+func (p *FakePin) Low() { p.Level = false }
+
+func (p *FakePin) Get() bool { return p.Level }
+
+// FakeRegister is a Register32 kept in memory.
+type FakeRegister struct {
+	Value uint32
+}
+
+func (r *FakeRegister) Get() uint32 { return r.Value }
+
+func (r *FakeRegister) Set(value uint32) { r.Value = value }
+
+// FakeTimer is a Timer that never runs by itself, Fire calls its handler.
+type FakeTimer struct {
+	Running bool
+	Period  uint32
+	Compare [2]uint32
+	handler func(channel int)
+}
+
+func (t *FakeTimer) Start(period uint32, handler func(channel int)) {
+	t.Running = true
+	t.Period = period
+	t.handler = handler
+}
+
+func (t *FakeTimer) Stop() { t.Running = false }
+
+func (t *FakeTimer) SetCompare(channel int, tick uint32) {
+	t.Compare[channel] = tick
+}
+
+// Fire calls the handler for channel as the real timer would, as long as
+// the timer is running.
+func (t *FakeTimer) Fire(channel int) {
+	if t.Running && t.handler != nil {
+		t.handler(channel)
+	}
+}
+
+// FakeADC is an ADC reading Value.
+type FakeADC struct {
+	Value uint16
+}
+
+func (a *FakeADC) Get() uint16 { return a.Value }
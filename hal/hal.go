@@ -0,0 +1,46 @@
+// Package hal abstracts the pins and peripherals used by ubit, so that the
+// same code drives the micro:bit on TinyGo and fakes on the host.
+package hal
+
+type PinMode uint8
+
+const (
+	PinOutput PinMode = iota
+	PinInput
+	// PinRelease disconnects the pin, leaving it to other users
+	PinRelease
+)
+
+// Pin is a GPIO pin.
+type Pin interface {
+	Configure(mode PinMode)
+	High()
+	Low()
+	Get() bool
+}
+
+// Register32 is a 32 bits peripheral register.
+type Register32 interface {
+	Get() uint32
+	Set(value uint32)
+}
+
+// Timer counts microseconds in periods. At the end of every period it
+// calls the handler for channel 0 and restarts the count, the handler is
+// called for channel 1 when the count reaches the compare value set with
+// SetCompare. The handler runs in interrupt context.
+type Timer interface {
+	Start(period uint32, handler func(channel int))
+	Stop()
+	SetCompare(channel int, tick uint32)
+}
+
+// ADC reads the voltage of an analog input, from 0 to 0xffff.
+type ADC interface {
+	Get() uint16
+}
+
+// Speaker plays a square wave, a frequency of 0 silences it.
+type Speaker interface {
+	Tone(frequency uint32)
+}
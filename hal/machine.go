@@ -0,0 +1,43 @@
+//go:build tinygo
+// +build tinygo
+
+package hal
+
+import (
+	"machine"
+
+	cnrf "github.com/wencode/ubit/nrf"
+)
+
+// MachinePin is a Pin backed by a pin of the chip.
+type MachinePin machine.Pin
+
+func (p MachinePin) Configure(mode PinMode) {
+	cfg := machine.PinConfig{Mode: machine.PinOutput}
+	switch mode {
+	case PinInput:
+		cfg.Mode = machine.PinInput
+	case PinRelease:
+		cfg.Mode = cnrf.DefaultPinMode
+	}
+	machine.Pin(p).Configure(cfg)
+}
+
+func (p MachinePin) High() { machine.Pin(p).High() }
+
+func (p MachinePin) Low() { machine.Pin(p).Low() }
+
+func (p MachinePin) Get() bool { return machine.Pin(p).Get() }
+
+// MachineADC is an ADC reading a pin of the chip.
+type MachineADC struct {
+	machine.ADC
+}
+
+// NewMachineADC turns the ADC on and configures pin as one of its inputs.
+func NewMachineADC(pin machine.Pin) *MachineADC {
+	machine.InitADC()
+	adc := &MachineADC{machine.ADC{Pin: pin}}
+	adc.Configure(machine.ADCConfig{})
+	return adc
+}
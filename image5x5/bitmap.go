@@ -0,0 +1,87 @@
+package image5x5
+
+// Bitmap is an image of any size, its pixels are stored row by row.
+// Pixels outside of it read as 0.
+type Bitmap struct {
+	Width  int
+	Height int
+	Pix    []uint8
+}
+
+func NewBitmap(width, height int) *Bitmap {
+	if width < 0 {
+		width = 0
+	}
+	if height < 0 {
+		height = 0
+	}
+	return &Bitmap{
+		Width:  width,
+		Height: height,
+		Pix:    make([]uint8, width*height),
+	}
+}
+
+// BitmapOf returns a 5x5 Bitmap with the pixels of img.
+func BitmapOf(img Image) *Bitmap {
+	b := NewBitmap(Width, Height)
+	copy(b.Pix, img[:])
+	return b
+}
+
+func (b *Bitmap) In(x, y int) bool {
+	return x >= 0 && x < b.Width && y >= 0 && y < b.Height
+}
+
+func (b *Bitmap) At(x, y int) uint8 {
+	if !b.In(x, y) {
+		return 0
+	}
+	return b.Pix[y*b.Width+x]
+}
+
+func (b *Bitmap) Set(x, y int, value uint8) {
+	if !b.In(x, y) {
+		return
+	}
+	b.Pix[y*b.Width+x] = value
+}
+
+// Crop returns the width*height part of b at x, y. The part may reach
+// outside of b, those pixels are 0.
+func (b *Bitmap) Crop(x, y, width, height int) *Bitmap {
+	c := NewBitmap(width, height)
+	for cy := 0; cy < c.Height; cy++ {
+		for cx := 0; cx < c.Width; cx++ {
+			c.Pix[cy*c.Width+cx] = b.At(x+cx, y+cy)
+		}
+	}
+	return c
+}
+
+// Shift returns a Bitmap of the same size with the content of b moved right
+// by dx and down by dy.
+func (b *Bitmap) Shift(dx, dy int) *Bitmap {
+	return b.Crop(-dx, -dy, b.Width, b.Height)
+}
+
+// Paste copies src into b with its top left corner at x, y. Pixels of src
+// falling outside of b are dropped.
+func (b *Bitmap) Paste(src *Bitmap, x, y int) {
+	for sy := 0; sy < src.Height; sy++ {
+		for sx := 0; sx < src.Width; sx++ {
+			b.Set(x+sx, y+sy, src.Pix[sy*src.Width+sx])
+		}
+	}
+}
+
+// Image returns the 5x5 part of b at x, y.
+func (b *Bitmap) Image(x, y int) Image {
+	var img Image
+	for iy := 0; iy < Height; iy++ {
+		for ix := 0; ix < Width; ix++ {
+			img[iy*Width+ix] = b.At(x+ix, y+iy)
+		}
+	}
+	return img
+}
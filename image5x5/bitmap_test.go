@@ -0,0 +1,45 @@
+package image5x5
+
+import (
+	"bytes"
+	"testing"
+)
+
+func bitmap(width, height int, pix ...uint8) *Bitmap {
+	return &Bitmap{Width: width, Height: height, Pix: pix}
+}
+
+func TestBitmap(t *testing.T) {
+	b := bitmap(3, 2,
+		1, 2, 3,
+		4, 5, 6,
+	)
+	cases := []struct {
+		name string
+		got  *Bitmap
+		want *Bitmap
+	}{
+		{"crop", b.Crop(1, 0, 2, 2), bitmap(2, 2, 2, 3, 5, 6)},
+		{"crop outside", b.Crop(2, 1, 2, 2), bitmap(2, 2, 6, 0, 0, 0)},
+		{"shift right", b.Shift(1, 0), bitmap(3, 2, 0, 1, 2, 0, 4, 5)},
+		{"shift up", b.Shift(0, -1), bitmap(3, 2, 4, 5, 6, 0, 0, 0)},
+		{"empty", NewBitmap(-1, 2), bitmap(0, 2)},
+	}
+	for _, c := range cases {
+		if c.got.Width != c.want.Width || c.got.Height != c.want.Height ||
+			!bytes.Equal(c.got.Pix, c.want.Pix) {
+			t.Errorf("%s: got %v, want %v", c.name, c.got, c.want)
+		}
+	}
+}
+
+func TestBitmapPaste(t *testing.T) {
+	b := NewBitmap(8, 5)
+	b.Paste(BitmapOf(Heart), 3, 0)
+	if b.At(4, 0) != Heart[1] || b.At(3, 1) != Heart[5] || b.At(2, 4) != 0 {
+		t.Errorf("pasted %v", b.Pix)
+	}
+	if img := b.Image(3, 0); img != Heart {
+		t.Errorf("viewport differs from pasted image")
+	}
+}
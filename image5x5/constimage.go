@@ -2,473 +2,581 @@ package image5x5
 
 var (
 	Heart = Image{
-		0, 1, 0, 1, 0,
-		1, 1, 1, 1, 1,
-		1, 1, 1, 1, 1,
-		0, 1, 1, 1, 0,
-		0, 0, 1, 0, 0,
+		0, 255, 0, 255, 0,
+		255, 255, 255, 255, 255,
+		255, 255, 255, 255, 255,
+		0, 255, 255, 255, 0,
+		0, 0, 255, 0, 0,
 	}
 
 	HeartSmall = Image{
 		0, 0, 0, 0, 0,
-		0, 1, 0, 1, 0,
-		0, 1, 1, 1, 0,
-		0, 0, 1, 0, 0,
+		0, 255, 0, 255, 0,
+		0, 255, 255, 255, 0,
+		0, 0, 255, 0, 0,
 		0, 0, 0, 0, 0,
 	}
 
 	Happy = Image{
 		0, 0, 0, 0, 0,
-		0, 1, 0, 1, 0,
+		0, 255, 0, 255, 0,
 		0, 0, 0, 0, 0,
-		1, 0, 0, 0, 1,
-		0, 1, 1, 1, 0,
+		255, 0, 0, 0, 255,
+		0, 255, 255, 255, 0,
 	}
 
 	Smile = Image{
 		0, 0, 0, 0, 0,
 		0, 0, 0, 0, 0,
 		0, 0, 0, 0, 0,
-		1, 0, 0, 0, 1,
-		0, 1, 1, 1, 0,
+		255, 0, 0, 0, 255,
+		0, 255, 255, 255, 0,
 	}
 
 	Sad = Image{
 		0, 0, 0, 0, 0,
-		0, 1, 0, 1, 0,
+		0, 255, 0, 255, 0,
 		0, 0, 0, 0, 0,
-		0, 1, 1, 1, 0,
-		1, 0, 0, 0, 1,
+		0, 255, 255, 255, 0,
+		255, 0, 0, 0, 255,
 	}
 
 	Confused = Image{
 		0, 0, 0, 0, 0,
-		0, 1, 0, 1, 0,
+		0, 255, 0, 255, 0,
 		0, 0, 0, 0, 0,
-		0, 1, 0, 1, 0,
-		1, 0, 1, 0, 1,
+		0, 255, 0, 255, 0,
+		255, 0, 255, 0, 255,
 	}
 
 	Angry = Image{
-		1, 0, 0, 0, 1,
-		0, 1, 0, 1, 0,
+		255, 0, 0, 0, 255,
+		0, 255, 0, 255, 0,
 		0, 0, 0, 0, 0,
-		1, 1, 1, 1, 1,
-		1, 0, 1, 0, 1,
+		255, 255, 255, 255, 255,
+		255, 0, 255, 0, 255,
 	}
 
 	ASleep = Image{
 		0, 0, 0, 0, 0,
-		1, 1, 0, 1, 1,
+		255, 255, 0, 255, 255,
 		0, 0, 0, 0, 0,
-		0, 1, 1, 1, 0,
+		0, 255, 255, 255, 0,
 		0, 0, 0, 0, 0,
 	}
 
 	Surprised = Image{
-		0, 1, 0, 1, 0,
+		0, 255, 0, 255, 0,
 		0, 0, 0, 0, 0,
-		0, 0, 1, 0, 0,
-		0, 1, 0, 1, 0,
-		0, 0, 1, 0, 0,
+		0, 0, 255, 0, 0,
+		0, 255, 0, 255, 0,
+		0, 0, 255, 0, 0,
 	}
 
 	Silly = Image{
-		1, 0, 0, 0, 1,
+		255, 0, 0, 0, 255,
 		0, 0, 0, 0, 0,
-		1, 1, 1, 1, 1,
-		0, 0, 1, 0, 1,
-		0, 0, 1, 1, 1,
+		255, 255, 255, 255, 255,
+		0, 0, 255, 0, 255,
+		0, 0, 255, 255, 255,
 	}
 
 	Fabulous = Image{
-		1, 1, 1, 1, 1,
-		1, 1, 0, 1, 1,
+		255, 255, 255, 255, 255,
+		255, 255, 0, 255, 255,
 		0, 0, 0, 0, 0,
-		0, 1, 0, 1, 0,
-		0, 1, 1, 1, 0,
+		0, 255, 0, 255, 0,
+		0, 255, 255, 255, 0,
 	}
 
 	Meh = Image{
-		0, 1, 0, 1, 0,
+		0, 255, 0, 255, 0,
 		0, 0, 0, 0, 0,
-		0, 0, 0, 1, 0,
-		0, 0, 1, 0, 0,
-		0, 1, 0, 0, 0,
+		0, 0, 0, 255, 0,
+		0, 0, 255, 0, 0,
+		0, 255, 0, 0, 0,
 	}
 
 	Yes = Image{
 		0, 0, 0, 0, 0,
-		0, 0, 0, 0, 1,
-		0, 0, 0, 1, 0,
-		1, 0, 1, 0, 0,
-		0, 1, 0, 0, 0,
+		0, 0, 0, 0, 255,
+		0, 0, 0, 255, 0,
+		255, 0, 255, 0, 0,
+		0, 255, 0, 0, 0,
 	}
 
 	No = Image{
-		1, 0, 0, 0, 1,
-		0, 1, 0, 1, 0,
-		0, 0, 1, 0, 0,
-		0, 1, 0, 1, 0,
-		1, 0, 0, 0, 1,
+		255, 0, 0, 0, 255,
+		0, 255, 0, 255, 0,
+		0, 0, 255, 0, 0,
+		0, 255, 0, 255, 0,
+		255, 0, 0, 0, 255,
 	}
 
 	Clock12 = Image{
-		0, 0, 1, 0, 0,
-		0, 0, 1, 0, 0,
-		0, 0, 1, 0, 0,
+		0, 0, 255, 0, 0,
+		0, 0, 255, 0, 0,
+		0, 0, 255, 0, 0,
 		0, 0, 0, 0, 0,
 		0, 0, 0, 0, 0,
 	}
 
-	Clock1_obj = Image{
-		0, 0, 0, 1, 0,
-		0, 0, 0, 1, 0,
-		0, 0, 1, 0, 0,
+	Clock1 = Image{
+		0, 0, 0, 255, 0,
+		0, 0, 0, 255, 0,
+		0, 0, 255, 0, 0,
 		0, 0, 0, 0, 0,
 		0, 0, 0, 0, 0,
 	}
-	Clock2_obj = Image{
+	Clock2 = Image{
 		0, 0, 0, 0, 0,
-		0, 0, 0, 1, 1,
-		0, 0, 1, 0, 0,
+		0, 0, 0, 255, 255,
+		0, 0, 255, 0, 0,
 		0, 0, 0, 0, 0,
 		0, 0, 0, 0, 0,
 	}
-	Clock3_obj = Image{
+	Clock3 = Image{
 		0, 0, 0, 0, 0,
 		0, 0, 0, 0, 0,
-		0, 0, 1, 1, 1,
+		0, 0, 255, 255, 255,
 		0, 0, 0, 0, 0,
 		0, 0, 0, 0, 0,
 	}
-	Clock4_obj = Image{
+	Clock4 = Image{
 		0, 0, 0, 0, 0,
 		0, 0, 0, 0, 0,
-		0, 0, 1, 0, 0,
-		0, 0, 0, 1, 1,
+		0, 0, 255, 0, 0,
+		0, 0, 0, 255, 255,
 		0, 0, 0, 0, 0,
 	}
-	Clock5_obj = Image{
+	Clock5 = Image{
 		0, 0, 0, 0, 0,
 		0, 0, 0, 0, 0,
-		0, 0, 1, 0, 0,
-		0, 0, 0, 1, 0,
-		0, 0, 0, 1, 0,
+		0, 0, 255, 0, 0,
+		0, 0, 0, 255, 0,
+		0, 0, 0, 255, 0,
 	}
-	Clock6_obj = Image{
+	Clock6 = Image{
 		0, 0, 0, 0, 0,
 		0, 0, 0, 0, 0,
-		0, 0, 1, 0, 0,
-		0, 0, 1, 0, 0,
-		0, 0, 1, 0, 0,
+		0, 0, 255, 0, 0,
+		0, 0, 255, 0, 0,
+		0, 0, 255, 0, 0,
 	}
-	Clock7_obj = Image{
+	Clock7 = Image{
 		0, 0, 0, 0, 0,
 		0, 0, 0, 0, 0,
-		0, 0, 1, 0, 0,
-		0, 1, 0, 0, 0,
-		0, 1, 0, 0, 0,
+		0, 0, 255, 0, 0,
+		0, 255, 0, 0, 0,
+		0, 255, 0, 0, 0,
 	}
-	Clock8_obj = Image{
+	Clock8 = Image{
 		0, 0, 0, 0, 0,
 		0, 0, 0, 0, 0,
-		0, 0, 1, 0, 0,
-		1, 1, 0, 0, 0,
+		0, 0, 255, 0, 0,
+		255, 255, 0, 0, 0,
 		0, 0, 0, 0, 0,
 	}
-	Clock9_obj = Image{
+	Clock9 = Image{
 		0, 0, 0, 0, 0,
 		0, 0, 0, 0, 0,
-		1, 1, 1, 0, 0,
+		255, 255, 255, 0, 0,
 		0, 0, 0, 0, 0,
 		0, 0, 0, 0, 0,
 	}
-	Clock10_obj = Image{
+	Clock10 = Image{
 		0, 0, 0, 0, 0,
-		1, 1, 0, 0, 0,
-		0, 0, 1, 0, 0,
+		255, 255, 0, 0, 0,
+		0, 0, 255, 0, 0,
 		0, 0, 0, 0, 0,
 		0, 0, 0, 0, 0,
 	}
-	Clock11_obj = Image{
-		0, 1, 0, 0, 0,
-		0, 1, 0, 0, 0,
-		0, 0, 1, 0, 0,
+	Clock11 = Image{
+		0, 255, 0, 0, 0,
+		0, 255, 0, 0, 0,
+		0, 0, 255, 0, 0,
 		0, 0, 0, 0, 0,
 		0, 0, 0, 0, 0,
 	}
 	// Arrows
 
-	Arrow_n_obj = Image{
-		0, 0, 1, 0, 0,
-		0, 1, 1, 1, 0,
-		1, 0, 1, 0, 1,
-		0, 0, 1, 0, 0,
-		0, 0, 1, 0, 0,
-	}
-	Arrow_ne_obj = Image{
-		0, 0, 1, 1, 1,
-		0, 0, 0, 1, 1,
-		0, 0, 1, 0, 1,
-		0, 1, 0, 0, 0,
-		1, 0, 0, 0, 0,
-	}
-	Arrow_e_obj = Image{
-		0, 0, 1, 0, 0,
-		0, 0, 0, 1, 0,
-		1, 1, 1, 1, 1,
-		0, 0, 0, 1, 0,
-		0, 0, 1, 0, 0,
-	}
-	Arrow_se_obj = Image{
-		1, 0, 0, 0, 0,
-		0, 1, 0, 0, 0,
-		0, 0, 1, 0, 1,
-		0, 0, 0, 1, 1,
-		0, 0, 1, 1, 1,
-	}
-	Arrow_s_obj = Image{
-		0, 0, 1, 0, 0,
-		0, 0, 1, 0, 0,
-		1, 0, 1, 0, 1,
-		0, 1, 1, 1, 0,
-		0, 0, 1, 0, 0,
-	}
-	Arrow_sw_obj = Image{
-		0, 0, 0, 0, 1,
-		0, 0, 0, 1, 0,
-		1, 0, 1, 0, 0,
-		1, 1, 0, 0, 0,
-		1, 1, 1, 0, 0,
-	}
-	Arrow_w_obj = Image{
-		0, 0, 1, 0, 0,
-		0, 1, 0, 0, 0,
-		1, 1, 1, 1, 1,
-		0, 1, 0, 0, 0,
-		0, 0, 1, 0, 0,
-	}
-	Arrow_nw_obj = Image{
-		1, 1, 1, 0, 0,
-		1, 1, 0, 0, 0,
-		1, 0, 1, 0, 0,
-		0, 0, 0, 1, 0,
-		0, 0, 0, 0, 1,
+	ArrowN = Image{
+		0, 0, 255, 0, 0,
+		0, 255, 255, 255, 0,
+		255, 0, 255, 0, 255,
+		0, 0, 255, 0, 0,
+		0, 0, 255, 0, 0,
+	}
+	ArrowNE = Image{
+		0, 0, 255, 255, 255,
+		0, 0, 0, 255, 255,
+		0, 0, 255, 0, 255,
+		0, 255, 0, 0, 0,
+		255, 0, 0, 0, 0,
+	}
+	ArrowE = Image{
+		0, 0, 255, 0, 0,
+		0, 0, 0, 255, 0,
+		255, 255, 255, 255, 255,
+		0, 0, 0, 255, 0,
+		0, 0, 255, 0, 0,
+	}
+	ArrowSE = Image{
+		255, 0, 0, 0, 0,
+		0, 255, 0, 0, 0,
+		0, 0, 255, 0, 255,
+		0, 0, 0, 255, 255,
+		0, 0, 255, 255, 255,
+	}
+	ArrowS = Image{
+		0, 0, 255, 0, 0,
+		0, 0, 255, 0, 0,
+		255, 0, 255, 0, 255,
+		0, 255, 255, 255, 0,
+		0, 0, 255, 0, 0,
+	}
+	ArrowSW = Image{
+		0, 0, 0, 0, 255,
+		0, 0, 0, 255, 0,
+		255, 0, 255, 0, 0,
+		255, 255, 0, 0, 0,
+		255, 255, 255, 0, 0,
+	}
+	ArrowW = Image{
+		0, 0, 255, 0, 0,
+		0, 255, 0, 0, 0,
+		255, 255, 255, 255, 255,
+		0, 255, 0, 0, 0,
+		0, 0, 255, 0, 0,
+	}
+	ArrowNW = Image{
+		255, 255, 255, 0, 0,
+		255, 255, 0, 0, 0,
+		255, 0, 255, 0, 0,
+		0, 0, 0, 255, 0,
+		0, 0, 0, 0, 255,
 	}
 	// geometry
 
-	Triangle_obj = Image{
+	Triangle = Image{
 		0, 0, 0, 0, 0,
-		0, 0, 1, 0, 0,
-		0, 1, 0, 1, 0,
-		1, 1, 1, 1, 1,
+		0, 0, 255, 0, 0,
+		0, 255, 0, 255, 0,
+		255, 255, 255, 255, 255,
 		0, 0, 0, 0, 0,
 	}
-	Triangle_left_obj = Image{
-		1, 0, 0, 0, 0,
-		1, 1, 0, 0, 0,
-		1, 0, 1, 0, 0,
-		1, 0, 0, 1, 0,
-		1, 1, 1, 1, 1,
+	TriangleLeft = Image{
+		255, 0, 0, 0, 0,
+		255, 255, 0, 0, 0,
+		255, 0, 255, 0, 0,
+		255, 0, 0, 255, 0,
+		255, 255, 255, 255, 255,
 	}
-	Chessboard_obj = Image{
-		0, 1, 0, 1, 0,
-		1, 0, 1, 0, 1,
-		0, 1, 0, 1, 0,
-		1, 0, 1, 0, 1,
-		0, 1, 0, 1, 0,
+	Chessboard = Image{
+		0, 255, 0, 255, 0,
+		255, 0, 255, 0, 255,
+		0, 255, 0, 255, 0,
+		255, 0, 255, 0, 255,
+		0, 255, 0, 255, 0,
 	}
-	Diamond_obj = Image{
-		0, 0, 1, 0, 0,
-		0, 1, 0, 1, 0,
-		1, 0, 0, 0, 1,
-		0, 1, 0, 1, 0,
-		0, 0, 1, 0, 0,
+	Diamond = Image{
+		0, 0, 255, 0, 0,
+		0, 255, 0, 255, 0,
+		255, 0, 0, 0, 255,
+		0, 255, 0, 255, 0,
+		0, 0, 255, 0, 0,
 	}
-	Diamond_small_obj = Image{
+	DiamondSmall = Image{
 		0, 0, 0, 0, 0,
-		0, 0, 1, 0, 0,
-		0, 1, 0, 1, 0,
-		0, 0, 1, 0, 0,
+		0, 0, 255, 0, 0,
+		0, 255, 0, 255, 0,
+		0, 0, 255, 0, 0,
 		0, 0, 0, 0, 0,
 	}
-	Square_obj = Image{
-		1, 1, 1, 1, 1,
-		1, 0, 0, 0, 1,
-		1, 0, 0, 0, 1,
-		1, 0, 0, 0, 1,
-		1, 1, 1, 1, 1,
+	Square = Image{
+		255, 255, 255, 255, 255,
+		255, 0, 0, 0, 255,
+		255, 0, 0, 0, 255,
+		255, 0, 0, 0, 255,
+		255, 255, 255, 255, 255,
 	}
-	Square_small_obj = Image{
+	SquareSmall = Image{
 		0, 0, 0, 0, 0,
-		0, 1, 1, 1, 0,
-		0, 1, 0, 1, 0,
-		0, 1, 1, 1, 0,
+		0, 255, 255, 255, 0,
+		0, 255, 0, 255, 0,
+		0, 255, 255, 255, 0,
 		0, 0, 0, 0, 0,
 	}
 	// animals
 
-	Rabbit_obj = Image{
-		1, 0, 1, 0, 0,
-		1, 0, 1, 0, 0,
-		1, 1, 1, 1, 0,
-		1, 1, 0, 1, 0,
-		1, 1, 1, 1, 0,
-	}
-	Cow_obj = Image{
-		1, 0, 0, 0, 1,
-		1, 0, 0, 0, 1,
-		1, 1, 1, 1, 1,
-		0, 1, 1, 1, 0,
-		0, 0, 1, 0, 0,
+	Rabbit = Image{
+		255, 0, 255, 0, 0,
+		255, 0, 255, 0, 0,
+		255, 255, 255, 255, 0,
+		255, 255, 0, 255, 0,
+		255, 255, 255, 255, 0,
+	}
+	Cow = Image{
+		255, 0, 0, 0, 255,
+		255, 0, 0, 0, 255,
+		255, 255, 255, 255, 255,
+		0, 255, 255, 255, 0,
+		0, 0, 255, 0, 0,
 	}
 	// musical notes
 
-	Music_crotchet_obj = Image{
-		0, 0, 1, 0, 0,
-		0, 0, 1, 0, 0,
-		0, 0, 1, 0, 0,
-		1, 1, 1, 0, 0,
-		1, 1, 1, 0, 0,
-	}
-	Music_quaver_obj = Image{
-		0, 0, 1, 0, 0,
-		0, 0, 1, 1, 0,
-		0, 0, 1, 0, 1,
-		1, 1, 1, 0, 0,
-		1, 1, 1, 0, 0,
-	}
-	Music_quavers_obj = Image{
-		0, 1, 1, 1, 1,
-		0, 1, 0, 0, 1,
-		0, 1, 0, 0, 1,
-		1, 1, 0, 1, 1,
-		1, 1, 0, 1, 1,
+	MusicCrotchet = Image{
+		0, 0, 255, 0, 0,
+		0, 0, 255, 0, 0,
+		0, 0, 255, 0, 0,
+		255, 255, 255, 0, 0,
+		255, 255, 255, 0, 0,
+	}
+	MusicQuaver = Image{
+		0, 0, 255, 0, 0,
+		0, 0, 255, 255, 0,
+		0, 0, 255, 0, 255,
+		255, 255, 255, 0, 0,
+		255, 255, 255, 0, 0,
+	}
+	MusicQuavers = Image{
+		0, 255, 255, 255, 255,
+		0, 255, 0, 0, 255,
+		0, 255, 0, 0, 255,
+		255, 255, 0, 255, 255,
+		255, 255, 0, 255, 255,
 	}
 	// other icons
 
-	Pitchfork_obj = Image{
-		1, 0, 1, 0, 1,
-		1, 0, 1, 0, 1,
-		1, 1, 1, 1, 1,
-		0, 0, 1, 0, 0,
-		0, 0, 1, 0, 0,
-	}
-	Xmas_obj = Image{
-		0, 0, 1, 0, 0,
-		0, 1, 1, 1, 0,
-		0, 0, 1, 0, 0,
-		0, 1, 1, 1, 0,
-		1, 1, 1, 1, 1,
-	}
-	Pacman_obj = Image{
-		0, 1, 1, 1, 1,
-		1, 1, 0, 1, 0,
-		1, 1, 1, 0, 0,
-		1, 1, 1, 1, 0,
-		0, 1, 1, 1, 1,
-	}
-	Target_obj = Image{
-		0, 0, 1, 0, 0,
-		0, 1, 1, 1, 0,
-		1, 1, 0, 1, 1,
-		0, 1, 1, 1, 0,
-		0, 0, 1, 0, 0,
+	Pitchfork = Image{
+		255, 0, 255, 0, 255,
+		255, 0, 255, 0, 255,
+		255, 255, 255, 255, 255,
+		0, 0, 255, 0, 0,
+		0, 0, 255, 0, 0,
+	}
+	Xmas = Image{
+		0, 0, 255, 0, 0,
+		0, 255, 255, 255, 0,
+		0, 0, 255, 0, 0,
+		0, 255, 255, 255, 0,
+		255, 255, 255, 255, 255,
+	}
+	Pacman = Image{
+		0, 255, 255, 255, 255,
+		255, 255, 0, 255, 0,
+		255, 255, 255, 0, 0,
+		255, 255, 255, 255, 0,
+		0, 255, 255, 255, 255,
+	}
+	Target = Image{
+		0, 0, 255, 0, 0,
+		0, 255, 255, 255, 0,
+		255, 255, 0, 255, 255,
+		0, 255, 255, 255, 0,
+		0, 0, 255, 0, 0,
 	}
 	/*
 	   The following images were designed by Abbie Brooks.
 	*/
 
-	Tshirt_obj = Image{
-		1, 1, 0, 1, 1,
-		1, 1, 1, 1, 1,
-		0, 1, 1, 1, 0,
-		0, 1, 1, 1, 0,
-		0, 1, 1, 1, 0,
-	}
-	Rollerskate_obj = Image{
-		0, 0, 0, 1, 1,
-		0, 0, 0, 1, 1,
-		1, 1, 1, 1, 1,
-		1, 1, 1, 1, 1,
-		0, 1, 0, 1, 0,
-	}
-	Duck_obj = Image{
-		0, 1, 1, 0, 0,
-		1, 1, 1, 0, 0,
-		0, 1, 1, 1, 1,
-		0, 1, 1, 1, 0,
-		0, 0, 0, 0, 0,
-	}
-	House_obj = Image{
-		0, 0, 1, 0, 0,
-		0, 1, 1, 1, 0,
-		1, 1, 1, 1, 1,
-		0, 1, 1, 1, 0,
-		0, 1, 0, 1, 0,
-	}
-	Tortoise_obj = Image{
-		0, 0, 0, 0, 0,
-		0, 1, 1, 1, 0,
-		1, 1, 1, 1, 1,
-		0, 1, 0, 1, 0,
-		0, 0, 0, 0, 0,
-	}
-	Butterfly_obj = Image{
-		1, 1, 0, 1, 1,
-		1, 1, 1, 1, 1,
-		0, 0, 1, 0, 0,
-		1, 1, 1, 1, 1,
-		1, 1, 0, 1, 1,
-	}
-	Stickfigure_obj = Image{
-		0, 0, 1, 0, 0,
-		1, 1, 1, 1, 1,
-		0, 0, 1, 0, 0,
-		0, 1, 0, 1, 0,
-		1, 0, 0, 0, 1,
-	}
-	Ghost_obj = Image{
-		1, 1, 1, 1, 1,
-		1, 0, 1, 0, 1,
-		1, 1, 1, 1, 1,
-		1, 1, 1, 1, 1,
-		1, 0, 1, 0, 1,
-	}
-	Sword_obj = Image{
-		0, 0, 1, 0, 0,
-		0, 0, 1, 0, 0,
-		0, 0, 1, 0, 0,
-		0, 1, 1, 1, 0,
-		0, 0, 1, 0, 0,
-	}
-	Giraffe_obj = Image{
-		1, 1, 0, 0, 0,
-		0, 1, 0, 0, 0,
-		0, 1, 0, 0, 0,
-		0, 1, 1, 1, 0,
-		0, 1, 0, 1, 0,
-	}
-	Skull_obj = Image{
-		0, 1, 1, 1, 0,
-		1, 0, 1, 0, 1,
-		1, 1, 1, 1, 1,
-		0, 1, 1, 1, 0,
-		0, 1, 1, 1, 0,
-	}
-	Umbrella_obj = Image{
-		0, 1, 1, 1, 0,
-		1, 1, 1, 1, 1,
-		0, 0, 1, 0, 0,
-		1, 0, 1, 0, 0,
-		0, 1, 1, 0, 0,
-	}
-	Snake_obj = Image{
-		1, 1, 0, 0, 0,
-		1, 1, 0, 1, 1,
-		0, 1, 0, 1, 0,
-		0, 1, 1, 1, 0,
+	TShirt = Image{
+		255, 255, 0, 255, 255,
+		255, 255, 255, 255, 255,
+		0, 255, 255, 255, 0,
+		0, 255, 255, 255, 0,
+		0, 255, 255, 255, 0,
+	}
+	Rollerskate = Image{
+		0, 0, 0, 255, 255,
+		0, 0, 0, 255, 255,
+		255, 255, 255, 255, 255,
+		255, 255, 255, 255, 255,
+		0, 255, 0, 255, 0,
+	}
+	Duck = Image{
+		0, 255, 255, 0, 0,
+		255, 255, 255, 0, 0,
+		0, 255, 255, 255, 255,
+		0, 255, 255, 255, 0,
+		0, 0, 0, 0, 0,
+	}
+	House = Image{
+		0, 0, 255, 0, 0,
+		0, 255, 255, 255, 0,
+		255, 255, 255, 255, 255,
+		0, 255, 255, 255, 0,
+		0, 255, 0, 255, 0,
+	}
+	Tortoise = Image{
+		0, 0, 0, 0, 0,
+		0, 255, 255, 255, 0,
+		255, 255, 255, 255, 255,
+		0, 255, 0, 255, 0,
+		0, 0, 0, 0, 0,
+	}
+	Butterfly = Image{
+		255, 255, 0, 255, 255,
+		255, 255, 255, 255, 255,
+		0, 0, 255, 0, 0,
+		255, 255, 255, 255, 255,
+		255, 255, 0, 255, 255,
+	}
+	Stickfigure = Image{
+		0, 0, 255, 0, 0,
+		255, 255, 255, 255, 255,
+		0, 0, 255, 0, 0,
+		0, 255, 0, 255, 0,
+		255, 0, 0, 0, 255,
+	}
+	Ghost = Image{
+		255, 255, 255, 255, 255,
+		255, 0, 255, 0, 255,
+		255, 255, 255, 255, 255,
+		255, 255, 255, 255, 255,
+		255, 0, 255, 0, 255,
+	}
+	Sword = Image{
+		0, 0, 255, 0, 0,
+		0, 0, 255, 0, 0,
+		0, 0, 255, 0, 0,
+		0, 255, 255, 255, 0,
+		0, 0, 255, 0, 0,
+	}
+	Giraffe = Image{
+		255, 255, 0, 0, 0,
+		0, 255, 0, 0, 0,
+		0, 255, 0, 0, 0,
+		0, 255, 255, 255, 0,
+		0, 255, 0, 255, 0,
+	}
+	Skull = Image{
+		0, 255, 255, 255, 0,
+		255, 0, 255, 0, 255,
+		255, 255, 255, 255, 255,
+		0, 255, 255, 255, 0,
+		0, 255, 255, 255, 0,
+	}
+	Umbrella = Image{
+		0, 255, 255, 255, 0,
+		255, 255, 255, 255, 255,
+		0, 0, 255, 0, 0,
+		255, 0, 255, 0, 0,
+		0, 255, 255, 0, 0,
+	}
+	Snake = Image{
+		255, 255, 0, 0, 0,
+		255, 255, 0, 255, 255,
+		0, 255, 0, 255, 0,
+		0, 255, 255, 255, 0,
 		0, 0, 0, 0, 0,
 	}
+
+	AllClocks = []Image{
+		Clock12, Clock1, Clock2, Clock3, Clock4, Clock5,
+		Clock6, Clock7, Clock8, Clock9, Clock10, Clock11,
+	}
+	AllArrows = []Image{
+		ArrowN, ArrowNE, ArrowE, ArrowSE, ArrowS, ArrowSW, ArrowW, ArrowNW,
+	}
+)
+
+// Names used before the images were renamed to Go style.
+var (
+	// Deprecated: use Clock1.
+	Clock1_obj = Clock1
+	// Deprecated: use Clock2.
+	Clock2_obj = Clock2
+	// Deprecated: use Clock3.
+	Clock3_obj = Clock3
+	// Deprecated: use Clock4.
+	Clock4_obj = Clock4
+	// Deprecated: use Clock5.
+	Clock5_obj = Clock5
+	// Deprecated: use Clock6.
+	Clock6_obj = Clock6
+	// Deprecated: use Clock7.
+	Clock7_obj = Clock7
+	// Deprecated: use Clock8.
+	Clock8_obj = Clock8
+	// Deprecated: use Clock9.
+	Clock9_obj = Clock9
+	// Deprecated: use Clock10.
+	Clock10_obj = Clock10
+	// Deprecated: use Clock11.
+	Clock11_obj = Clock11
+	// Deprecated: use ArrowN.
+	Arrow_n_obj = ArrowN
+	// Deprecated: use ArrowNE.
+	Arrow_ne_obj = ArrowNE
+	// Deprecated: use ArrowE.
+	Arrow_e_obj = ArrowE
+	// Deprecated: use ArrowSE.
+	Arrow_se_obj = ArrowSE
+	// Deprecated: use ArrowS.
+	Arrow_s_obj = ArrowS
+	// Deprecated: use ArrowSW.
+	Arrow_sw_obj = ArrowSW
+	// Deprecated: use ArrowW.
+	Arrow_w_obj = ArrowW
+	// Deprecated: use ArrowNW.
+	Arrow_nw_obj = ArrowNW
+	// Deprecated: use Triangle.
+	Triangle_obj = Triangle
+	// Deprecated: use TriangleLeft.
+	Triangle_left_obj = TriangleLeft
+	// Deprecated: use Chessboard.
+	Chessboard_obj = Chessboard
+	// Deprecated: use Diamond.
+	Diamond_obj = Diamond
+	// Deprecated: use DiamondSmall.
+	Diamond_small_obj = DiamondSmall
+	// Deprecated: use Square.
+	Square_obj = Square
+	// Deprecated: use SquareSmall.
+	Square_small_obj = SquareSmall
+	// Deprecated: use Rabbit.
+	Rabbit_obj = Rabbit
+	// Deprecated: use Cow.
+	Cow_obj = Cow
+	// Deprecated: use MusicCrotchet.
+	Music_crotchet_obj = MusicCrotchet
+	// Deprecated: use MusicQuaver.
+	Music_quaver_obj = MusicQuaver
+	// Deprecated: use MusicQuavers.
+	Music_quavers_obj = MusicQuavers
+	// Deprecated: use Pitchfork.
+	Pitchfork_obj = Pitchfork
+	// Deprecated: use Xmas.
+	Xmas_obj = Xmas
+	// Deprecated: use Pacman.
+	Pacman_obj = Pacman
+	// Deprecated: use Target.
+	Target_obj = Target
+	// Deprecated: use TShirt.
+	Tshirt_obj = TShirt
+	// Deprecated: use Rollerskate.
+	Rollerskate_obj = Rollerskate
+	// Deprecated: use Duck.
+	Duck_obj = Duck
+	// Deprecated: use House.
+	House_obj = House
+	// Deprecated: use Tortoise.
+	Tortoise_obj = Tortoise
+	// Deprecated: use Butterfly.
+	Butterfly_obj = Butterfly
+	// Deprecated: use Stickfigure.
+	Stickfigure_obj = Stickfigure
+	// Deprecated: use Ghost.
+	Ghost_obj = Ghost
+	// Deprecated: use Sword.
+	Sword_obj = Sword
+	// Deprecated: use Giraffe.
+	Giraffe_obj = Giraffe
+	// Deprecated: use Skull.
+	Skull_obj = Skull
+	// Deprecated: use Umbrella.
+	Umbrella_obj = Umbrella
+	// Deprecated: use Snake.
+	Snake_obj = Snake
 )
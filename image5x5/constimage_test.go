@@ -0,0 +1,31 @@
+package image5x5
+
+import (
+	"testing"
+)
+
+func TestConstImages(t *testing.T) {
+	cases := []struct {
+		img Image
+		s   string
+	}{
+		{Clock3, "00000:00000:00999:00000:00000"},
+		{ArrowSW, "00009:00090:90900:99000:99900"},
+		{Pacman, "09999:99090:99900:99990:09999"},
+		{Snake, "99000:99099:09090:09990:00000"},
+	}
+	for _, c := range cases {
+		if s := c.img.String(); s != c.s {
+			t.Errorf("got %q, want %q", s, c.s)
+		}
+	}
+	if len(AllClocks) != 12 || AllClocks[0] != Clock12 || AllClocks[11] != Clock11 {
+		t.Errorf("AllClocks out of order")
+	}
+	if len(AllArrows) != 8 || AllArrows[0] != ArrowN || AllArrows[7] != ArrowNW {
+		t.Errorf("AllArrows out of order")
+	}
+	if Clock1_obj != Clock1 || Arrow_n_obj != ArrowN || Tshirt_obj != TShirt {
+		t.Errorf("deprecated names differ from their images")
+	}
+}
@@ -6,3 +6,117 @@ const (
 )
 
 type Image [Width * Height]uint8
+
+// Fill returns an image with every pixel set to value.
+func Fill(value uint8) Image {
+	var img Image
+	for i := range img {
+		img[i] = value
+	}
+	return img
+}
+
+func (img Image) At(x, y int) uint8 {
+	if x < 0 || x >= Width || y < 0 || y >= Height {
+		return 0
+	}
+	return img[y*Width+x]
+}
+
+func (img Image) Equal(other Image) bool {
+	return img == other
+}
+
+// Invert returns img with every pixel's brightness reversed.
+func (img Image) Invert() Image {
+	for i := range img {
+		img[i] = 255 - img[i]
+	}
+	return img
+}
+
+// FlipH returns img mirrored left to right.
+func (img Image) FlipH() Image {
+	var out Image
+	for y := 0; y < Height; y++ {
+		for x := 0; x < Width; x++ {
+			out[y*Width+x] = img[y*Width+Width-1-x]
+		}
+	}
+	return out
+}
+
+// FlipV returns img mirrored top to bottom.
+func (img Image) FlipV() Image {
+	var out Image
+	for y := 0; y < Height; y++ {
+		copy(out[y*Width:(y+1)*Width], img[(Height-1-y)*Width:])
+	}
+	return out
+}
+
+// Rotate90 returns img turned a quarter counter-clockwise, as shown by
+// ModDisplay.Rotate(1).
+func (img Image) Rotate90() Image {
+	var out Image
+	for y := 0; y < Height; y++ {
+		for x := 0; x < Width; x++ {
+			out[y*Width+x] = img[x*Width+Width-1-y]
+		}
+	}
+	return out
+}
+
+// Shift returns img moved right by dx and down by dy, the pixels moved in
+// are 0.
+func (img Image) Shift(dx, dy int) Image {
+	var out Image
+	for y := 0; y < Height; y++ {
+		for x := 0; x < Width; x++ {
+			out[y*Width+x] = img.At(x-dx, y-dy)
+		}
+	}
+	return out
+}
+
+// Add returns the sum of both images, saturating at 255.
+func (img Image) Add(other Image) Image {
+	for i := range img {
+		v := uint16(img[i]) + uint16(other[i])
+		if v > 255 {
+			v = 255
+		}
+		img[i] = uint8(v)
+	}
+	return img
+}
+
+// Sub returns img minus other, saturating at 0.
+func (img Image) Sub(other Image) Image {
+	for i := range img {
+		if img[i] > other[i] {
+			img[i] -= other[i]
+		} else {
+			img[i] = 0
+		}
+	}
+	return img
+}
+
+// Scale returns img with every pixel multiplied by brightness/255.
+func (img Image) Scale(brightness uint8) Image {
+	for i := range img {
+		img[i] = uint8(uint16(img[i]) * uint16(brightness) / 255)
+	}
+	return img
+}
+
+// Blend returns a mix of both images, from img at alpha 0 to other at
+// alpha 255.
+func (img Image) Blend(other Image, alpha uint8) Image {
+	for i := range img {
+		from, to := int32(img[i]), int32(other[i])
+		img[i] = uint8(from + (to-from)*int32(alpha)/255)
+	}
+	return img
+}
@@ -0,0 +1,94 @@
+package image5x5
+
+import (
+	"testing"
+)
+
+var (
+	corner = Image{
+		255, 128, 0, 0, 0,
+		0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0,
+		64, 0, 0, 0, 0,
+	}
+	half = Fill(128)
+)
+
+func TestImageOps(t *testing.T) {
+	cases := []struct {
+		name string
+		got  Image
+		want Image
+	}{
+		{"fill", Fill(7), Image{7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7}},
+		{"invert", Fill(55).Invert(), Fill(200)},
+		{"invert twice", corner.Invert().Invert(), corner},
+		{"flip h", corner.FlipH(), Image{
+			0, 0, 0, 128, 255,
+			0, 0, 0, 0, 0,
+			0, 0, 0, 0, 0,
+			0, 0, 0, 0, 0,
+			0, 0, 0, 0, 64,
+		}},
+		{"flip v", corner.FlipV(), Image{
+			64, 0, 0, 0, 0,
+			0, 0, 0, 0, 0,
+			0, 0, 0, 0, 0,
+			0, 0, 0, 0, 0,
+			255, 128, 0, 0, 0,
+		}},
+		{"rotate", corner.Rotate90(), Image{
+			0, 0, 0, 0, 0,
+			0, 0, 0, 0, 0,
+			0, 0, 0, 0, 0,
+			128, 0, 0, 0, 0,
+			255, 0, 0, 0, 64,
+		}},
+		{"rotate full turn", corner.Rotate90().Rotate90().Rotate90().Rotate90(), corner},
+		{"rotate half turn", corner.Rotate90().Rotate90(), corner.FlipH().FlipV()},
+		{"shift", corner.Shift(1, -1), Image{
+			0, 0, 0, 0, 0,
+			0, 0, 0, 0, 0,
+			0, 0, 0, 0, 0,
+			0, 64, 0, 0, 0,
+			0, 0, 0, 0, 0,
+		}},
+		{"shift out", corner.Shift(Width, 0), Image{}},
+		{"add", corner.Add(half), Image{
+			255, 255, 128, 128, 128,
+			128, 128, 128, 128, 128,
+			128, 128, 128, 128, 128,
+			128, 128, 128, 128, 128,
+			192, 128, 128, 128, 128,
+		}},
+		{"sub", corner.Sub(half), Image{127}},
+		{"scale", corner.Scale(128), Image{
+			128, 64, 0, 0, 0,
+			0, 0, 0, 0, 0,
+			0, 0, 0, 0, 0,
+			0, 0, 0, 0, 0,
+			32, 0, 0, 0, 0,
+		}},
+		{"scale full", corner.Scale(255), corner},
+		{"blend start", corner.Blend(half, 0), corner},
+		{"blend end", corner.Blend(half, 255), half},
+		{"blend half", Fill(0).Blend(Fill(200), 128), Fill(100)},
+	}
+	for _, c := range cases {
+		if !c.got.Equal(c.want) {
+			t.Errorf("%s: got %v, want %v", c.name, c.got, c.want)
+		}
+	}
+}
+
+func TestImageUnchanged(t *testing.T) {
+	img := corner
+	img.Invert()
+	img.Add(half)
+	img.Scale(0)
+	img.Blend(half, 100)
+	if img != corner {
+		t.Errorf("image changed to %v", img)
+	}
+}
@@ -0,0 +1,62 @@
+package image5x5
+
+import (
+	"github.com/wencode/ubit/common"
+)
+
+// Parse reads an image in MicroPython notation, five rows of five digits
+// from 0 (off) to 9 (full brightness) separated by ':' or newlines, such as
+// "09090:99999:99999:09990:00900". A trailing separator is allowed.
+func Parse(s string) (Image, error) {
+	var img Image
+	x, y := 0, 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == ':' || c == '\n':
+			if x != Width {
+				return Image{}, common.ErrInvalidArgument
+			}
+			x = 0
+			y++
+		case c >= '0' && c <= '9':
+			if x >= Width || y >= Height {
+				return Image{}, common.ErrInvalidArgument
+			}
+			img[y*Width+x] = uint8(uint16(c-'0') * 255 / 9)
+			x++
+		default:
+			return Image{}, common.ErrInvalidArgument
+		}
+	}
+	if !(y == Height-1 && x == Width) && !(y == Height && x == 0) {
+		return Image{}, common.ErrInvalidArgument
+	}
+	return img, nil
+}
+
+// MustParse is like Parse but panics if s is not a valid image. It is meant
+// for package level variables.
+func MustParse(s string) Image {
+	img, err := Parse(s)
+	if err != nil {
+		panic("image5x5: invalid image " + s)
+	}
+	return img
+}
+
+// String formats img in the notation read by Parse, brightness rounded to
+// the nearest of the ten levels.
+func (img Image) String() string {
+	buf := make([]byte, 0, Height*(Width+1)-1)
+	for y := 0; y < Height; y++ {
+		if y > 0 {
+			buf = append(buf, ':')
+		}
+		for x := 0; x < Width; x++ {
+			level := (uint16(img[y*Width+x])*9 + 127) / 255
+			buf = append(buf, '0'+byte(level))
+		}
+	}
+	return string(buf)
+}
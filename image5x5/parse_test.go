@@ -0,0 +1,47 @@
+package image5x5
+
+import (
+	"testing"
+
+	"github.com/wencode/ubit/common"
+)
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		s    string
+		want Image
+		err  error
+	}{
+		{"09090:99999:99999:09990:00900", Heart, nil},
+		{"09090:99999:99999:09990:00900:", Heart, nil},
+		{"09090\n99999\n99999\n09990\n00900\n", Heart, nil},
+		{"12345:00000:00000:00000:00000", Image{28, 56, 85, 113, 141}, nil},
+		{"", Image{}, common.ErrInvalidArgument},
+		{"09090:99999:99999:09990", Image{}, common.ErrInvalidArgument},
+		{"09090:99999:99999:09990:00900:00000", Image{}, common.ErrInvalidArgument},
+		{"0909:99999:99999:09990:00900", Image{}, common.ErrInvalidArgument},
+		{"090900:99999:99999:09990:00900", Image{}, common.ErrInvalidArgument},
+		{"0909a:99999:99999:09990:00900", Image{}, common.ErrInvalidArgument},
+		{"09090::99999:99999:09990:00900", Image{}, common.ErrInvalidArgument},
+	}
+	for _, c := range cases {
+		img, err := Parse(c.s)
+		if err != c.err || img != c.want {
+			t.Errorf("Parse(%q) = %v, %v, want %v, %v", c.s, img, err, c.want, c.err)
+		}
+	}
+}
+
+func TestStringRoundTrip(t *testing.T) {
+	for _, s := range []string{
+		"09090:99999:99999:09990:00900",
+		"01234:56789:98765:43210:00000",
+	} {
+		if got := MustParse(s).String(); got != s {
+			t.Errorf("String() = %q, want %q", got, s)
+		}
+	}
+	if s := Fill(200).String(); s != "77777:77777:77777:77777:77777" {
+		t.Errorf("String() = %q", s)
+	}
+}
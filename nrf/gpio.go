@@ -0,0 +1,30 @@
+//go:build tinygo
+// +build tinygo
+
+package nrf
+
+import (
+	"device/nrf"
+	"machine"
+)
+
+const (
+	DefaultPinMode = machine.PinMode(nrf.GPIO_PIN_CNF_DIR_Input |
+		nrf.GPIO_PIN_CNF_INPUT_Disconnect |
+		nrf.GPIO_PIN_CNF_PULL_Disabled |
+		nrf.GPIO_PIN_CNF_DRIVE_S0S1 |
+		nrf.GPIO_PIN_CNF_SENSE_Disabled)
+)
+
+func GetPortPin(p machine.Pin) (*nrf.GPIO_Type, uint32) {
+	if p >= 32 {
+		return nrf.P1, uint32(p - 32)
+	} else {
+		return nrf.P0, uint32(p)
+	}
+}
+
+func GPIO_Cfg_Default(pin_number uint32) {
+	pin := machine.Pin(pin_number)
+	pin.Configure(machine.PinConfig{DefaultPinMode})
+}
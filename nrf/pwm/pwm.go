@@ -1,12 +1,8 @@
 package pwm
 
 import (
-	"device/nrf"
-	"machine"
-	"runtime/interrupt"
-	"runtime/volatile"
-
 	"github.com/wencode/ubit/common"
+	"github.com/wencode/ubit/hal"
 	cnrf "github.com/wencode/ubit/nrf"
 )
 
@@ -46,57 +42,110 @@ const (
 	EventLoopsDone          //0x11c
 )
 
+// values of PRESCALER, the base clock is 16MHz divided by 2^value
 const (
-	CLK_16MHz  = nrf.PWM_PRESCALER_PRESCALER_DIV_1
-	CLK_8MHz   = nrf.PWM_PRESCALER_PRESCALER_DIV_2
-	CLK_4MHz   = nrf.PWM_PRESCALER_PRESCALER_DIV_4
-	CLK_2MHz   = nrf.PWM_PRESCALER_PRESCALER_DIV_2
-	CLK_1MHz   = nrf.PWM_PRESCALER_PRESCALER_DIV_16
-	CLK_500KHz = nrf.PWM_PRESCALER_PRESCALER_DIV_32
-	CLK_250KHz = nrf.PWM_PRESCALER_PRESCALER_DIV_64
-	CLK_125KHz = nrf.PWM_PRESCALER_PRESCALER_DIV_128
+	CLK_16MHz  = 0
+	CLK_8MHz   = 1
+	CLK_4MHz   = 2
+	CLK_2MHz   = 3
+	CLK_1MHz   = 4
+	CLK_500KHz = 5
+	CLK_250KHz = 6
+	CLK_125KHz = 7
 
 	clock_frequency = 16000000
 )
 
+// register fields, see the PWM chapter of the nRF52833 product specification
+const (
+	pwm_ENABLE_Disabled = 0
+	pwm_ENABLE_Enabled  = 1
+
+	pwm_MODE_Up        = 0
+	pwm_MODE_UpAndDown = 1
+
+	pwm_DECODER_LOAD_Pos          = 0
+	pwm_DECODER_LOAD_Common       = 0
+	pwm_DECODER_MODE_Pos          = 8
+	pwm_DECODER_MODE_RefreshCount = 0
+
+	pwm_SHORTS_LOOPSDONE_SEQSTART0 = 1 << 2
+	pwm_SHORTS_LOOPSDONE_SEQSTART1 = 1 << 3
+	pwm_SHORTS_LOOPSDONE_STOP      = 1 << 4
+
+	pwm_INTEN_STOPPED   = 1 << 1
+	pwm_INTEN_SEQEND0   = 1 << 4
+	pwm_INTEN_SEQEND1   = 1 << 5
+	pwm_INTEN_LOOPSDONE = 1 << 7
+)
+
 var (
 	_pwms = [4]PWM{
 		{
-			PWM_Type: nrf.PWM0,
-			id:       -1,
+			Registers: pwm_newRegisters(ID0),
+			id:        -1,
 		},
 		{
-			PWM_Type: nrf.PWM1,
-			id:       -1,
+			Registers: pwm_newRegisters(ID1),
+			id:        -1,
 		},
 		{
-			PWM_Type: nrf.PWM2,
-			id:       -1,
+			Registers: pwm_newRegisters(ID2),
+			id:        -1,
 		},
 		{
-			PWM_Type: nrf.PWM3,
-			id:       -1,
+			Registers: pwm_newRegisters(ID3),
+			id:        -1,
 		},
 	}
 )
 
+// Registers are the registers of a PWM peripheral used by the driver.
+type Registers struct {
+	TASKS_STOP          hal.Register32
+	TASKS_SEQSTART      [2]hal.Register32
+	TASKS_NEXTSTEP      hal.Register32
+	EVENTS_STOPPED      hal.Register32
+	EVENTS_SEQSTARTED   [2]hal.Register32
+	EVENTS_SEQEND       [2]hal.Register32
+	EVENTS_PWMPERIODEND hal.Register32
+	EVENTS_LOOPSDONE    hal.Register32
+	SHORTS              hal.Register32
+	INTEN               hal.Register32
+	ENABLE              hal.Register32
+	MODE                hal.Register32
+	COUNTERTOP          hal.Register32
+	PRESCALER           hal.Register32
+	DECODER             hal.Register32
+	LOOP                hal.Register32
+	SEQ                 [2]struct {
+		PTR      hal.Register32
+		CNT      hal.Register32
+		REFRESH  hal.Register32
+		ENDDELAY hal.Register32
+	}
+	PSEL struct {
+		OUT [CHANNEL_COUNT]hal.Register32
+	}
+}
+
 type Handler func(event Event, context interface{})
 
 type PWM struct {
-	*nrf.PWM_Type
+	Registers
 	id      int32
 	handler Handler
 	context interface{}
-	state   volatile.Register32
+	state   stateRegister
 	flags   uint8
 
-	ir   interrupt.Interrupt
+	ir   pwmIRQ
 	seq0 *Sequence
 	seq1 *Sequence
 }
 
 type Config struct {
-	output_pins   [CHANNEL_COUNT]machine.Pin
+	output_pins   [CHANNEL_COUNT]Pin
 	handler       Handler
 	context       interface{}
 	base_clock    uint32
@@ -110,25 +159,25 @@ type Config struct {
 
 func pwm_defaultConfig() Config {
 	return Config{
-		output_pins: [CHANNEL_COUNT]machine.Pin{
-			machine.NoPin,
-			machine.NoPin,
-			machine.NoPin,
-			machine.NoPin,
+		output_pins: [CHANNEL_COUNT]Pin{
+			NoPin,
+			NoPin,
+			NoPin,
+			NoPin,
 		},
 		irq_priority:  6,
 		base_clock:    CLK_8MHz,
-		count_mode:    nrf.PWM_MODE_UPDOWN_Up,
+		count_mode:    pwm_MODE_Up,
 		top_value:     255,
-		dec_load:      nrf.PWM_DECODER_LOAD_Common,
-		dec_mode:      nrf.PWM_DECODER_MODE_RefreshCount,
+		dec_load:      pwm_DECODER_LOAD_Common,
+		dec_mode:      pwm_DECODER_MODE_RefreshCount,
 		skip_gpio_cfg: false,
 	}
 }
 
 type Option func(*Config)
 
-func WithOutputPin(pins ...machine.Pin) Option {
+func WithOutputPin(pins ...Pin) Option {
 	return func(cfg *Config) {
 		pins_len := len(pins)
 		if pins_len > CHANNEL_COUNT {
@@ -178,15 +227,15 @@ func Init(id ID, opts ...Option) (*PWM, error) {
 	pwm.handler = cfg.handler
 	pwm.context = cfg.context
 
-	pwm_configurePins(pwm.PWM_Type, &cfg)
+	pwm_configurePins(&pwm.Registers, &cfg)
 
-	pwm.ENABLE.Set(nrf.PWM_ENABLE_ENABLE_Enabled << nrf.PWM_ENABLE_ENABLE_Pos)
-	pwm.PRESCALER.Set(cfg.base_clock << nrf.PWM_PRESCALER_PRESCALER_Pos)
-	pwm.MODE.Set(cfg.count_mode << nrf.PWM_MODE_UPDOWN_Pos)
-	pwm.COUNTERTOP.Set(uint32(cfg.top_value) << nrf.PWM_COUNTERTOP_COUNTERTOP_Pos)
+	pwm.ENABLE.Set(pwm_ENABLE_Enabled)
+	pwm.PRESCALER.Set(cfg.base_clock)
+	pwm.MODE.Set(cfg.count_mode)
+	pwm.COUNTERTOP.Set(uint32(cfg.top_value))
 
-	pwm.DECODER.Set((cfg.dec_load << nrf.PWM_DECODER_LOAD_Pos) |
-		(cfg.dec_mode << nrf.PWM_DECODER_MODE_Pos))
+	pwm.DECODER.Set((cfg.dec_load << pwm_DECODER_LOAD_Pos) |
+		(cfg.dec_mode << pwm_DECODER_MODE_Pos))
 
 	pwm.SHORTS.Set(0)
 	pwm.INTEN.Set(0)
@@ -196,30 +245,10 @@ func Init(id ID, opts ...Option) (*PWM, error) {
 	pwm.EVENTS_STOPPED.Set(0)
 
 	if cfg.handler != nil {
-		var ir interrupt.Interrupt
-		switch id {
-		case ID0:
-			ir = interrupt.New(nrf.IRQ_PWM0, func(_ir interrupt.Interrupt) {
-				_pwms[0].irqHandler(_ir)
-			})
-		case ID1:
-			ir = interrupt.New(nrf.IRQ_PWM1, func(_ir interrupt.Interrupt) {
-				_pwms[1].irqHandler(_ir)
-			})
-		case ID2:
-			ir = interrupt.New(nrf.IRQ_PWM2, func(_ir interrupt.Interrupt) {
-				_pwms[2].irqHandler(_ir)
-			})
-		case ID3:
-			ir = interrupt.New(nrf.IRQ_PWM3, func(_ir interrupt.Interrupt) {
-				_pwms[3].irqHandler(_ir)
-			})
-		}
-		ir.SetPriority(cfg.irq_priority)
-		ir.Enable()
+		pwm.ir = pwm_newIRQ(id)
+		pwm.ir.SetPriority(cfg.irq_priority)
+		pwm.ir.Enable()
 		println("open irq ", id, " ", cfg.irq_priority)
-
-		pwm.ir = ir
 	}
 
 	pwm.state.Set(cnrf.DriverInitialized)
@@ -233,15 +262,16 @@ func (p *PWM) Uninit() {
 		p.handler = nil
 	}
 
-	p.ENABLE.Set(nrf.PWM_ENABLE_ENABLE_Disabled << nrf.PWM_ENABLE_ENABLE_Pos)
-	pwm_deconfigurePins(p.PWM_Type)
+	p.ENABLE.Set(pwm_ENABLE_Disabled)
+	pwm_deconfigurePins(&p.Registers)
 
 	p.state.Set(cnrf.DriverUninitialized)
+	p.id = -1
 }
 
 func (p *PWM) SimplePlayback(seq *Sequence, playback_count uint16) {
-	seq.setTo(p.PWM_Type, 0)
-	seq.setTo(p.PWM_Type, 1)
+	seq.setTo(&p.Registers, 0)
+	seq.setTo(&p.Registers, 1)
 	p.seq0 = seq
 	p.seq1 = seq
 	odd := (playback_count&1 == 1)
@@ -254,12 +284,12 @@ func (p *PWM) SimplePlayback(seq *Sequence, playback_count uint16) {
 	shorts_mask := uint32(0)
 	if playback_count > 1 {
 		if odd {
-			shorts_mask = nrf.PWM_SHORTS_LOOPSDONE_SEQSTART1_Msk
+			shorts_mask = pwm_SHORTS_LOOPSDONE_SEQSTART1
 		} else {
-			shorts_mask = nrf.PWM_SHORTS_LOOPSDONE_SEQSTART0_Msk
+			shorts_mask = pwm_SHORTS_LOOPSDONE_SEQSTART0
 		}
 	} else {
-		shorts_mask = nrf.PWM_SHORTS_LOOPSDONE_STOP_Msk
+		shorts_mask = pwm_SHORTS_LOOPSDONE_STOP
 	}
 	p.SHORTS.Set(shorts_mask)
 
@@ -271,17 +301,17 @@ func (p *PWM) SimplePlayback(seq *Sequence, playback_count uint16) {
 }
 
 func (p *PWM) Playback(seq0, seq1 *Sequence, playback_count uint16) {
-	seq0.setTo(p.PWM_Type, 0)
-	seq1.setTo(p.PWM_Type, 1)
+	seq0.setTo(&p.Registers, 0)
+	seq1.setTo(&p.Registers, 1)
 	p.seq0 = seq0
 	p.seq1 = seq1
 	p.LOOP.Set(uint32(playback_count))
 
 	shorts_mask := uint32(0)
 	if playback_count > 1 {
-		shorts_mask = nrf.PWM_SHORTS_LOOPSDONE_SEQSTART0_Msk
+		shorts_mask = pwm_SHORTS_LOOPSDONE_SEQSTART0
 	} else {
-		shorts_mask = nrf.PWM_SHORTS_LOOPSDONE_STOP_Msk
+		shorts_mask = pwm_SHORTS_LOOPSDONE_STOP
 	}
 	p.SHORTS.Set(shorts_mask)
 
@@ -294,7 +324,7 @@ func (p *PWM) Stop(wait_until_stopped bool) bool {
 	// the same peripheral clock cycle as the STOP task was triggered.
 	p.SHORTS.Set(0)
 
-	pwm_taskTrigger(p.PWM_Type, TaskStop)
+	pwm_taskTrigger(&p.Registers, TaskStop)
 
 	if p.IsStopped() {
 		return true
@@ -319,23 +349,23 @@ func (p *PWM) IsStopped() bool {
 	return true
 }
 
-func (p *PWM) irqHandler(ir interrupt.Interrupt) {
-	if e := common.Volatile32_GetAndClear(&p.EVENTS_SEQEND[0]); e != 0 {
+func (p *PWM) irqHandler() {
+	if e := common.Volatile32_GetAndClear(p.EVENTS_SEQEND[0]); e != 0 {
 		if p.handler != nil {
 			p.handler(EventSeqEnd0, p.context)
 		}
 	}
-	if e := common.Volatile32_GetAndClear(&p.EVENTS_SEQEND[1]); e != 0 {
+	if e := common.Volatile32_GetAndClear(p.EVENTS_SEQEND[1]); e != 0 {
 		if p.handler != nil {
 			p.handler(EventSeqEnd1, p.context)
 		}
 	}
-	if e := common.Volatile32_GetAndClear(&p.EVENTS_LOOPSDONE); e != 0 {
+	if e := common.Volatile32_GetAndClear(p.EVENTS_LOOPSDONE); e != 0 {
 		if p.handler != nil {
 			p.handler(EventLoopsDone, p.context)
 		}
 	}
-	if e := common.Volatile32_GetAndClear(&p.EVENTS_STOPPED); e != 0 {
+	if e := common.Volatile32_GetAndClear(p.EVENTS_STOPPED); e != 0 {
 		p.state.Set(cnrf.DriverInitialized)
 		if p.handler != nil {
 			p.handler(EventStopped, p.context)
@@ -347,32 +377,26 @@ func (p *PWM) startPlayback(starting_task Task) {
 	p.state.Set(cnrf.DriverStatePoweredOn)
 
 	if p.handler != nil {
-		int_mask := uint32(nrf.PWM_INTEN_LOOPSDONE_Msk |
-			nrf.PWM_INTEN_STOPPED_Msk |
-			nrf.PWM_INTEN_SEQEND0_Msk |
-			nrf.PWM_INTEN_SEQEND1_Msk)
+		int_mask := uint32(pwm_INTEN_LOOPSDONE |
+			pwm_INTEN_STOPPED |
+			pwm_INTEN_SEQEND0 |
+			pwm_INTEN_SEQEND1)
 		p.INTEN.Set(int_mask)
 	}
 
 	p.EVENTS_STOPPED.Set(0)
-	pwm_taskTrigger(p.PWM_Type, starting_task)
+	pwm_taskTrigger(&p.Registers, starting_task)
 }
 
-func pwm_configurePins(p *nrf.PWM_Type, cfg *Config) {
+func pwm_configurePins(p *Registers, cfg *Config) {
 	for i := 0; i < CHANNEL_COUNT; i++ {
 		pin := uint32(PIN_NOT_CONNECTED)
-		if output_pin := cfg.output_pins[i]; output_pin != machine.NoPin {
+		if output_pin := cfg.output_pins[i]; output_pin != NoPin {
 			pin = uint32(output_pin) & (^uint32(PIN_INVERTED))
 
 			if !cfg.skip_gpio_cfg {
 				inverted := (output_pin&PIN_INVERTED != 0)
-				port, pin_number := cnrf.GetPortPin(machine.Pin(pin))
-				if inverted {
-					port.OUTSET.Set(uint32(1) << pin_number)
-				} else {
-					port.OUTCLR.Set(uint32(1) << pin_number)
-				}
-				machine.Pin(pin).Configure(machine.PinConfig{machine.PinOutput})
+				pwm_configurePin(Pin(pin), inverted)
 			}
 
 		}
@@ -380,16 +404,16 @@ func pwm_configurePins(p *nrf.PWM_Type, cfg *Config) {
 	}
 }
 
-func pwm_deconfigurePins(p *nrf.PWM_Type) {
+func pwm_deconfigurePins(p *Registers) {
 	for i := 0; i < CHANNEL_COUNT; i++ {
 		output_in := p.PSEL.OUT[i].Get()
 		if output_in != PIN_NOT_CONNECTED {
-			cnrf.GPIO_Cfg_Default(output_in)
+			pwm_releasePin(output_in)
 		}
 	}
 }
 
-func pwm_taskTrigger(p *nrf.PWM_Type, task Task) {
+func pwm_taskTrigger(p *Registers, task Task) {
 	switch task {
 	case TaskStop:
 		p.TASKS_STOP.Set(1)
@@ -0,0 +1,47 @@
+//go:build !tinygo
+// +build !tinygo
+
+package pwm
+
+import (
+	"github.com/wencode/ubit/hal"
+)
+
+// On the host the PWMs are backed by hal.FakeRegister, which tests inspect
+// and set to raise events.
+
+type Pin uint8
+
+const NoPin Pin = 0xff
+
+type stateRegister = hal.FakeRegister
+
+type pwmIRQ struct{}
+
+func (pwmIRQ) SetPriority(priority uint8) {}
+
+func (pwmIRQ) Enable() {}
+
+func (pwmIRQ) Disable() {}
+
+func pwm_newRegisters(id ID) Registers {
+	var r Registers
+	for _, reg := range []*hal.Register32{
+		&r.TASKS_STOP, &r.TASKS_SEQSTART[0], &r.TASKS_SEQSTART[1], &r.TASKS_NEXTSTEP,
+		&r.EVENTS_STOPPED, &r.EVENTS_SEQSTARTED[0], &r.EVENTS_SEQSTARTED[1],
+		&r.EVENTS_SEQEND[0], &r.EVENTS_SEQEND[1], &r.EVENTS_PWMPERIODEND, &r.EVENTS_LOOPSDONE,
+		&r.SHORTS, &r.INTEN, &r.ENABLE, &r.MODE, &r.COUNTERTOP, &r.PRESCALER, &r.DECODER, &r.LOOP,
+		&r.SEQ[0].PTR, &r.SEQ[0].CNT, &r.SEQ[0].REFRESH, &r.SEQ[0].ENDDELAY,
+		&r.SEQ[1].PTR, &r.SEQ[1].CNT, &r.SEQ[1].REFRESH, &r.SEQ[1].ENDDELAY,
+		&r.PSEL.OUT[0], &r.PSEL.OUT[1], &r.PSEL.OUT[2], &r.PSEL.OUT[3],
+	} {
+		*reg = &hal.FakeRegister{}
+	}
+	return r
+}
+
+func pwm_newIRQ(id ID) pwmIRQ { return pwmIRQ{} }
+
+func pwm_configurePin(pin Pin, inverted bool) {}
+
+func pwm_releasePin(pin_number uint32) {}
@@ -0,0 +1,122 @@
+package pwm
+
+import (
+	"testing"
+
+	"github.com/wencode/ubit/hal"
+)
+
+func TestInit(t *testing.T) {
+	p, err := Init(ID1, WithBaseCLK(CLK_2MHz), WithTopValue(1000), WithOutputPin(3, NoPin, 5|PIN_INVERTED))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Init(ID1); err == nil {
+		t.Error("initialized twice")
+	}
+	cases := []struct {
+		name string
+		r    hal.Register32
+		want uint32
+	}{
+		{"ENABLE", p.ENABLE, pwm_ENABLE_Enabled},
+		{"PRESCALER", p.PRESCALER, 3},
+		{"MODE", p.MODE, pwm_MODE_Up},
+		{"COUNTERTOP", p.COUNTERTOP, 1000},
+		{"DECODER", p.DECODER, 0},
+		{"PSEL.OUT[0]", p.PSEL.OUT[0], 3},
+		{"PSEL.OUT[1]", p.PSEL.OUT[1], PIN_NOT_CONNECTED},
+		{"PSEL.OUT[2]", p.PSEL.OUT[2], 5},
+		{"PSEL.OUT[3]", p.PSEL.OUT[3], PIN_NOT_CONNECTED},
+	}
+	for _, c := range cases {
+		if got := c.r.Get(); got != c.want {
+			t.Errorf("%s = %#x, want %#x", c.name, got, c.want)
+		}
+	}
+
+	p.Uninit()
+	if p.ENABLE.Get() != pwm_ENABLE_Disabled {
+		t.Error("still enabled after Uninit")
+	}
+	if p, err = Init(ID1); err != nil {
+		t.Errorf("init after Uninit: %v", err)
+	} else {
+		p.Uninit()
+	}
+}
+
+func TestSimplePlayback(t *testing.T) {
+	cases := []struct {
+		count  uint16
+		loop   uint32
+		shorts uint32
+		task   int
+	}{
+		{1, 1, pwm_SHORTS_LOOPSDONE_STOP, 1},
+		{2, 1, pwm_SHORTS_LOOPSDONE_STOP, 0},
+		{4, 2, pwm_SHORTS_LOOPSDONE_SEQSTART0, 0},
+		{5, 3, pwm_SHORTS_LOOPSDONE_SEQSTART1, 1},
+	}
+	for _, c := range cases {
+		p, err := Init(ID0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		seq := NewSequence([]uint16{1, 2, 3})
+		seq.SetRepeated(2)
+		p.SimplePlayback(seq, c.count)
+		if p.LOOP.Get() != c.loop || p.SHORTS.Get() != c.shorts {
+			t.Errorf("count %d: LOOP %d SHORTS %#x, want %d %#x",
+				c.count, p.LOOP.Get(), p.SHORTS.Get(), c.loop, c.shorts)
+		}
+		for i := 0; i < 2; i++ {
+			if p.SEQ[i].CNT.Get() != 3 || p.SEQ[i].REFRESH.Get() != 2 {
+				t.Errorf("count %d: SEQ[%d] CNT %d REFRESH %d", c.count, i,
+					p.SEQ[i].CNT.Get(), p.SEQ[i].REFRESH.Get())
+			}
+		}
+		if p.TASKS_SEQSTART[c.task].Get() != 1 || p.TASKS_SEQSTART[1-c.task].Get() != 0 {
+			t.Errorf("count %d: started sequence %d", c.count, 1-c.task)
+		}
+		if p.IsStopped() {
+			t.Errorf("count %d: stopped while playing", c.count)
+		}
+		p.EVENTS_STOPPED.Set(1)
+		if !p.IsStopped() {
+			t.Errorf("count %d: not stopped", c.count)
+		}
+		p.Uninit()
+		p.TASKS_SEQSTART[0].Set(0)
+		p.TASKS_SEQSTART[1].Set(0)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	var events []Event
+	p, err := Init(ID2, WithHandler(func(event Event, context interface{}) {
+		events = append(events, event)
+	}, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Uninit()
+	p.Playback(NewSequence([]uint16{1}), NewSequence([]uint16{2}), 1)
+	want := uint32(pwm_INTEN_LOOPSDONE | pwm_INTEN_STOPPED | pwm_INTEN_SEQEND0 | pwm_INTEN_SEQEND1)
+	if p.INTEN.Get() != want {
+		t.Errorf("INTEN %#x, want %#x", p.INTEN.Get(), want)
+	}
+
+	p.EVENTS_SEQEND[1].Set(1)
+	p.EVENTS_STOPPED.Set(1)
+	p.irqHandler()
+	if len(events) != 2 || events[0] != EventSeqEnd1 || events[1] != EventStopped {
+		t.Errorf("events %x", events)
+	}
+	if p.EVENTS_SEQEND[1].Get() != 0 || p.EVENTS_STOPPED.Get() != 0 {
+		t.Error("events not cleared")
+	}
+	if !p.IsStopped() {
+		t.Error("not stopped after EventStopped")
+	}
+}
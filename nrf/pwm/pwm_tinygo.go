@@ -0,0 +1,89 @@
+//go:build tinygo
+// +build tinygo
+
+package pwm
+
+import (
+	"device/nrf"
+	"machine"
+	"runtime/interrupt"
+	"runtime/volatile"
+
+	cnrf "github.com/wencode/ubit/nrf"
+)
+
+type Pin = machine.Pin
+
+const NoPin = machine.NoPin
+
+type stateRegister = volatile.Register32
+
+type pwmIRQ = interrupt.Interrupt
+
+func pwm_newRegisters(id ID) Registers {
+	p := [4]*nrf.PWM_Type{nrf.PWM0, nrf.PWM1, nrf.PWM2, nrf.PWM3}[id]
+	r := Registers{
+		TASKS_STOP:          &p.TASKS_STOP,
+		TASKS_NEXTSTEP:      &p.TASKS_NEXTSTEP,
+		EVENTS_STOPPED:      &p.EVENTS_STOPPED,
+		EVENTS_PWMPERIODEND: &p.EVENTS_PWMPERIODEND,
+		EVENTS_LOOPSDONE:    &p.EVENTS_LOOPSDONE,
+		SHORTS:              &p.SHORTS,
+		INTEN:               &p.INTEN,
+		ENABLE:              &p.ENABLE,
+		MODE:                &p.MODE,
+		COUNTERTOP:          &p.COUNTERTOP,
+		PRESCALER:           &p.PRESCALER,
+		DECODER:             &p.DECODER,
+		LOOP:                &p.LOOP,
+	}
+	for i := 0; i < 2; i++ {
+		r.TASKS_SEQSTART[i] = &p.TASKS_SEQSTART[i]
+		r.EVENTS_SEQSTARTED[i] = &p.EVENTS_SEQSTARTED[i]
+		r.EVENTS_SEQEND[i] = &p.EVENTS_SEQEND[i]
+		r.SEQ[i].PTR = &p.SEQ[i].PTR
+		r.SEQ[i].CNT = &p.SEQ[i].CNT
+		r.SEQ[i].REFRESH = &p.SEQ[i].REFRESH
+		r.SEQ[i].ENDDELAY = &p.SEQ[i].ENDDELAY
+	}
+	for i := 0; i < CHANNEL_COUNT; i++ {
+		r.PSEL.OUT[i] = &p.PSEL.OUT[i]
+	}
+	return r
+}
+
+func pwm_newIRQ(id ID) pwmIRQ {
+	switch id {
+	case ID0:
+		return interrupt.New(nrf.IRQ_PWM0, func(interrupt.Interrupt) {
+			_pwms[0].irqHandler()
+		})
+	case ID1:
+		return interrupt.New(nrf.IRQ_PWM1, func(interrupt.Interrupt) {
+			_pwms[1].irqHandler()
+		})
+	case ID2:
+		return interrupt.New(nrf.IRQ_PWM2, func(interrupt.Interrupt) {
+			_pwms[2].irqHandler()
+		})
+	}
+	return interrupt.New(nrf.IRQ_PWM3, func(interrupt.Interrupt) {
+		_pwms[3].irqHandler()
+	})
+}
+
+// pwm_configurePin drives pin to its idle level before it is handed to the
+// PWM, high when the output is inverted.
+func pwm_configurePin(pin Pin, inverted bool) {
+	port, pin_number := cnrf.GetPortPin(pin)
+	if inverted {
+		port.OUTSET.Set(uint32(1) << pin_number)
+	} else {
+		port.OUTCLR.Set(uint32(1) << pin_number)
+	}
+	pin.Configure(machine.PinConfig{machine.PinOutput})
+}
+
+func pwm_releasePin(pin_number uint32) {
+	cnrf.GPIO_Cfg_Default(pin_number)
+}
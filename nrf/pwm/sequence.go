@@ -4,8 +4,6 @@ import (
 	"reflect"
 	"unsafe"
 
-	"device/nrf"
-
 	"github.com/wencode/ubit/common"
 )
 
@@ -53,7 +51,7 @@ func (seq *Sequence) SetRepeated(n int) { seq.repeated = uint32(n) }
 
 func (seq *Sequence) SetEndDelay(v int) { seq.end_delay = uint32(v) }
 
-func (seq *Sequence) setTo(p *nrf.PWM_Type, seq_id int) {
+func (seq *Sequence) setTo(p *Registers, seq_id int) {
 	values_header := (*reflect.SliceHeader)(unsafe.Pointer(&seq.values))
 	p.SEQ[seq_id].PTR.Set(uint32(values_header.Data))
 	p.SEQ[seq_id].CNT.Set(uint32(values_header.Len))
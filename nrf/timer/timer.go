@@ -0,0 +1,126 @@
+//go:build tinygo
+// +build tinygo
+
+package timer
+
+import (
+	"device/nrf"
+	"runtime/interrupt"
+)
+
+type ID int32
+
+const (
+	ID0 ID = iota
+	ID1
+	ID2
+	ID3
+	ID4
+)
+
+const (
+	// 16MHz >> 4 = 1MHz, one tick per microsecond
+	prescaler_1MHz = 4
+)
+
+var (
+	_timers = [5]Timer{
+		{TIMER_Type: nrf.TIMER0},
+		{TIMER_Type: nrf.TIMER1},
+		{TIMER_Type: nrf.TIMER2},
+		{TIMER_Type: nrf.TIMER3},
+		{TIMER_Type: nrf.TIMER4},
+	}
+)
+
+// Timer is a hal.Timer on a TIMER peripheral, COMPARE0 ends a period and
+// clears the counter, COMPARE1 is free to move within the period.
+type Timer struct {
+	*nrf.TIMER_Type
+	handler func(channel int)
+	ir      interrupt.Interrupt
+	irqSet  bool
+}
+
+func Get(id ID) *Timer {
+	return &(_timers[id])
+}
+
+// Start runs the timer, calling handler at interrupt priority.
+func (t *Timer) Start(period uint32, handler func(channel int)) {
+	t.TASKS_STOP.Set(1)
+	t.handler = handler
+	t.MODE.Set(nrf.TIMER_MODE_MODE_Timer << nrf.TIMER_MODE_MODE_Pos)
+	t.BITMODE.Set(nrf.TIMER_BITMODE_BITMODE_32Bit << nrf.TIMER_BITMODE_BITMODE_Pos)
+	t.PRESCALER.Set(prescaler_1MHz << nrf.TIMER_PRESCALER_PRESCALER_Pos)
+	t.CC[0].Set(period)
+	t.CC[1].Set(0xFFFFFFFF)
+	t.SHORTS.Set(nrf.TIMER_SHORTS_COMPARE0_CLEAR_Msk)
+	t.EVENTS_COMPARE[0].Set(0)
+	t.EVENTS_COMPARE[1].Set(0)
+	t.INTENSET.Set(nrf.TIMER_INTENSET_COMPARE0_Msk | nrf.TIMER_INTENSET_COMPARE1_Msk)
+
+	if !t.irqSet {
+		t.ir = timer_newIRQ(t)
+		t.irqSet = true
+	}
+	t.ir.Enable()
+
+	t.TASKS_CLEAR.Set(1)
+	t.TASKS_START.Set(1)
+}
+
+func (t *Timer) Stop() {
+	t.TASKS_STOP.Set(1)
+	t.INTENCLR.Set(nrf.TIMER_INTENCLR_COMPARE0_Msk | nrf.TIMER_INTENCLR_COMPARE1_Msk)
+	t.ir.Disable()
+}
+
+func (t *Timer) SetCompare(channel int, tick uint32) {
+	t.CC[channel].Set(tick)
+}
+
+// SetPriority sets the priority of the interrupt calling the handler, it
+// takes effect from the next Start.
+func (t *Timer) SetPriority(priority uint8) {
+	if !t.irqSet {
+		t.ir = timer_newIRQ(t)
+		t.irqSet = true
+	}
+	t.ir.SetPriority(priority)
+}
+
+func (t *Timer) irqHandler(ir interrupt.Interrupt) {
+	if t.EVENTS_COMPARE[0].Get() != 0 {
+		t.EVENTS_COMPARE[0].Set(0)
+		t.handler(0)
+	}
+	if t.EVENTS_COMPARE[1].Get() != 0 {
+		t.EVENTS_COMPARE[1].Set(0)
+		t.handler(1)
+	}
+}
+
+func timer_newIRQ(t *Timer) interrupt.Interrupt {
+	switch t.TIMER_Type {
+	case nrf.TIMER0:
+		return interrupt.New(nrf.IRQ_TIMER0, func(ir interrupt.Interrupt) {
+			_timers[0].irqHandler(ir)
+		})
+	case nrf.TIMER1:
+		return interrupt.New(nrf.IRQ_TIMER1, func(ir interrupt.Interrupt) {
+			_timers[1].irqHandler(ir)
+		})
+	case nrf.TIMER2:
+		return interrupt.New(nrf.IRQ_TIMER2, func(ir interrupt.Interrupt) {
+			_timers[2].irqHandler(ir)
+		})
+	case nrf.TIMER3:
+		return interrupt.New(nrf.IRQ_TIMER3, func(ir interrupt.Interrupt) {
+			_timers[3].irqHandler(ir)
+		})
+	}
+	return interrupt.New(nrf.IRQ_TIMER4, func(ir interrupt.Interrupt) {
+		_timers[4].irqHandler(ir)
+	})
+}
@@ -0,0 +1,220 @@
+// Package sim simulates the led matrix, the buttons and the speaker of the
+// micro:bit in a terminal, so that ubit programs run on the host.
+package sim
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/wencode/ubit/hal"
+)
+
+const (
+	Width  = 5
+	Height = 5
+)
+
+// Board is a simulated micro:bit. Its pins and timer are handed to the
+// drivers in place of the hardware, the matrix is reconstructed from what
+// the drivers do with them.
+type Board struct {
+	Rows    [Height]*hal.FakePin
+	Cols    [Width]*hal.FakePin
+	ButtonA *Button
+	ButtonB *Button
+
+	// mu serialises the timer handler, which stands for the interrupts of
+	// the board, and the output
+	mu       sync.Mutex
+	out      io.Writer
+	in       io.Reader
+	listen   sync.Once
+	timer    Timer
+	speaker  speaker
+	last     Frame
+	drawn    bool
+	realtime bool
+	// ambient light level, read with atomic
+	light int32
+}
+
+// NewBoard returns a board drawing to out and reading keys from in, which
+// may be nil. Its timer runs in real time until SetRealtime(false), and
+// the light is half way between dark and bright.
+func NewBoard(out io.Writer, in io.Reader) *Board {
+	b := &Board{
+		out:      out,
+		in:       in,
+		realtime: true,
+		light:    128,
+	}
+	for i := range b.Rows {
+		b.Rows[i] = &hal.FakePin{}
+	}
+	for i := range b.Cols {
+		b.Cols[i] = &hal.FakePin{Level: true}
+	}
+	b.ButtonA = &Button{b: b}
+	b.ButtonB = &Button{b: b}
+	b.timer.b = b
+	b.speaker.b = b
+	return b
+}
+
+func (b *Board) Timer() hal.Timer { return &b.timer }
+
+func (b *Board) Speaker() hal.Speaker { return &b.speaker }
+
+// SetRealtime tells whether the timer started from now on runs by itself,
+// drawing every new frame. Without it, frames are scanned by Step only.
+func (b *Board) SetRealtime(realtime bool) {
+	b.realtime = realtime
+}
+
+// Step scans one frame with the timer handler and returns what it showed.
+func (b *Board) Step() Frame {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.scan()
+}
+
+// Render draws f over the frame drawn before, unless it is the same.
+func (b *Board) Render(f Frame) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.render(f)
+}
+
+// scan runs the handler through the rows of a frame, as the timer would.
+// The brightness of a led is the part of its row period during which its
+// row is high and its column low.
+func (b *Board) scan() Frame {
+	var f Frame
+	t := &b.timer
+	if t.handler == nil || t.period == 0 {
+		return f
+	}
+	// one more row period for the light sensor, if any
+	for i := 0; i < Height+1; i++ {
+		t.handler(0)
+		row := b.row()
+		var on [Width]uint32
+		for x, col := range b.Cols {
+			if row >= 0 && !col.Get() {
+				on[x] = t.period
+			}
+		}
+		for n := 0; n <= Width; n++ {
+			tick := t.compare[1]
+			if tick >= t.period {
+				break
+			}
+			t.handler(1)
+			for x, col := range b.Cols {
+				if on[x] == t.period && col.Get() {
+					on[x] = tick
+				}
+			}
+		}
+		if row < 0 {
+			continue
+		}
+		for x := range on {
+			f[row*Width+x] = uint8((on[x]*255 + t.period - 1) / t.period)
+		}
+		if row == Height-1 {
+			break
+		}
+	}
+	return f
+}
+
+// row returns the row driven high, -1 if none is.
+func (b *Board) row() int {
+	for y, pin := range b.Rows {
+		if pin.Get() {
+			return y
+		}
+	}
+	return -1
+}
+
+func (b *Board) render(f Frame) {
+	if b.drawn && f == b.last {
+		return
+	}
+	s := f.String()
+	if b.drawn {
+		s = fmt.Sprintf("\x1b[%dA", Height) + s
+	}
+	io.WriteString(b.out, s)
+	b.last = f
+	b.drawn = true
+}
+
+// logf prints a line below the frame, the next frame is drawn below it.
+func (b *Board) logf(format string, args ...interface{}) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	fmt.Fprintf(b.out, format+"\n", args...)
+	b.drawn = false
+}
+
+// Timer is the timer of a Board, its period and compare values are in
+// microseconds.
+type Timer struct {
+	b       *Board
+	period  uint32
+	compare [2]uint32
+	handler func(channel int)
+	quitch  chan struct{}
+	done    chan struct{}
+}
+
+func (t *Timer) Start(period uint32, handler func(channel int)) {
+	t.Stop()
+	t.b.mu.Lock()
+	t.period = period
+	t.handler = handler
+	t.b.mu.Unlock()
+	if t.b.realtime {
+		t.quitch = make(chan struct{})
+		t.done = make(chan struct{})
+		go t.run()
+	}
+}
+
+func (t *Timer) Stop() {
+	if t.quitch != nil {
+		close(t.quitch)
+		<-t.done
+		t.quitch = nil
+	}
+	t.b.mu.Lock()
+	t.handler = nil
+	t.b.mu.Unlock()
+}
+
+func (t *Timer) SetCompare(channel int, tick uint32) {
+	t.compare[channel] = tick
+}
+
+// run scans and draws a frame every Height periods, the time the board
+// takes for one.
+func (t *Timer) run() {
+	defer close(t.done)
+	ticker := time.NewTicker(time.Duration(t.period) * time.Microsecond * Height)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-t.quitch:
+			return
+		case <-ticker.C:
+			t.b.mu.Lock()
+			t.b.render(t.b.scan())
+			t.b.mu.Unlock()
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package sim
+
+import (
+	"fmt"
+	"strings"
+)
+
+// 256 colour greys the leds are drawn with, an off led is a dim dot
+const (
+	grey_off = 236
+	grey_min = 239
+	grey_max = 255
+)
+
+// Frame is the brightness of every led, row by row.
+type Frame [Width * Height]uint8
+
+// String draws f as Height lines of ANSI coloured blocks, brighter leds in
+// lighter greys.
+func (f Frame) String() string {
+	var sb strings.Builder
+	for y := 0; y < Height; y++ {
+		for x := 0; x < Width; x++ {
+			v := int(f[y*Width+x])
+			if v == 0 {
+				fmt.Fprintf(&sb, "\x1b[38;5;%dm· ", grey_off)
+				continue
+			}
+			grey := grey_min + (v-1)*(grey_max-grey_min)/254
+			fmt.Fprintf(&sb, "\x1b[38;5;%dm██", grey)
+		}
+		sb.WriteString("\x1b[0m\n")
+	}
+	return sb.String()
+}
@@ -0,0 +1,111 @@
+package sim
+
+import (
+	"bufio"
+	"sync/atomic"
+	"time"
+
+	"github.com/wencode/ubit/hal"
+)
+
+const (
+	// milliseconds a button is held down for a key
+	key_press_ms = 100
+	// change of the light level for a key
+	key_light_step = 32
+)
+
+// Button is the pin of a button of a Board. Like on the board it reads
+// low while the button is pressed.
+type Button struct {
+	b       *Board
+	pressed int32
+}
+
+// Configure starts reading keys, see readKeys, once the button is used as
+// an input.
+func (p *Button) Configure(mode hal.PinMode) {
+	if mode == hal.PinInput {
+		p.b.listen.Do(p.b.readKeys)
+	}
+}
+
+func (p *Button) High() {}
+
+func (p *Button) Low() {}
+
+func (p *Button) Get() bool { return atomic.LoadInt32(&p.pressed) == 0 }
+
+// Press holds the button down for ms milliseconds.
+func (p *Button) Press(ms int) {
+	atomic.AddInt32(&p.pressed, 1)
+	time.AfterFunc(time.Duration(ms)*time.Millisecond, func() {
+		atomic.AddInt32(&p.pressed, -1)
+	})
+}
+
+// readKeys acts on the keys read from in: a presses button A, b presses B
+// and space both, + and - change the light level. The terminal stays
+// line-buffered, so keys take effect only once return is pressed. Each key
+// is a short press: a button cannot be held down, as a terminal reports no
+// key releases.
+func (b *Board) readKeys() {
+	if b.in == nil {
+		return
+	}
+	go func() {
+		r := bufio.NewReader(b.in)
+		for {
+			c, err := r.ReadByte()
+			if err != nil {
+				return
+			}
+			switch c {
+			case 'a', 'A':
+				b.ButtonA.Press(key_press_ms)
+			case 'b', 'B':
+				b.ButtonB.Press(key_press_ms)
+			case ' ':
+				b.ButtonA.Press(key_press_ms)
+				b.ButtonB.Press(key_press_ms)
+			case '+':
+				b.addLight(key_light_step)
+			case '-':
+				b.addLight(-key_light_step)
+			}
+		}
+	}()
+}
+
+// SetLight sets the ambient light sensed by the leds, from 0 (dark) to 255
+// (bright).
+func (b *Board) SetLight(level uint8) {
+	atomic.StoreInt32(&b.light, int32(level))
+}
+
+func (b *Board) addLight(step int32) {
+	level := atomic.LoadInt32(&b.light) + step
+	if level < 0 {
+		level = 0
+	}
+	if level > 255 {
+		level = 255
+	}
+	atomic.StoreInt32(&b.light, level)
+}
+
+// SenseADC returns the analog input of a matrix column. It reads the
+// voltage the light set with SetLight leaves on the leds: full scale in
+// the dark, 0 in bright light. Keys are read from then on, see readKeys.
+func (b *Board) SenseADC(col int) hal.ADC {
+	b.listen.Do(b.readKeys)
+	return senseADC{b}
+}
+
+type senseADC struct {
+	b *Board
+}
+
+func (a senseADC) Get() uint16 {
+	return uint16(255-atomic.LoadInt32(&a.b.light)) << 8
+}
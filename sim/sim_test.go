@@ -0,0 +1,76 @@
+package sim
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wencode/ubit/hal"
+)
+
+func TestNoteName(t *testing.T) {
+	cases := []struct {
+		frequency uint32
+		want      string
+	}{
+		{440, "A4"},
+		{262, "C4"},
+		{277, "C#4"},
+		{880, "A5"},
+		{131, "C3"},
+	}
+	for _, c := range cases {
+		if got := noteName(c.frequency); got != c.want {
+			t.Errorf("noteName(%d) = %s, want %s", c.frequency, got, c.want)
+		}
+	}
+}
+
+func TestSpeakerLog(t *testing.T) {
+	var out bytes.Buffer
+	b := NewBoard(&out, nil)
+	s := b.Speaker()
+	s.Tone(440)
+	s.Tone(440)
+	s.Tone(0)
+	if got, want := out.String(), "audio: A4 440Hz\naudio: off\n"; got != want {
+		t.Errorf("logged %q, want %q", got, want)
+	}
+}
+
+func TestKeys(t *testing.T) {
+	b := NewBoard(nil, strings.NewReader("a\n"))
+	if !b.ButtonA.Get() {
+		t.Error("button A pressed before reading keys")
+	}
+	b.ButtonA.Configure(hal.PinInput)
+	deadline := time.Now().Add(time.Second)
+	for b.ButtonA.Get() {
+		if time.Now().After(deadline) {
+			t.Fatal("key a did not press button A")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if !b.ButtonB.Get() {
+		t.Error("key a pressed button B")
+	}
+}
+
+func TestLight(t *testing.T) {
+	b := NewBoard(nil, nil)
+	adc := b.SenseADC(0)
+	b.SetLight(0)
+	if got := adc.Get(); got != 255<<8 {
+		t.Errorf("ADC %d in the dark", got)
+	}
+	b.SetLight(255)
+	if got := adc.Get(); got != 0 {
+		t.Errorf("ADC %d in bright light", got)
+	}
+	b.SetLight(100)
+	b.addLight(-key_light_step)
+	if got := adc.Get(); got != (255-100+key_light_step)<<8 {
+		t.Errorf("ADC %d after -", got)
+	}
+}
@@ -0,0 +1,36 @@
+package sim
+
+import (
+	"fmt"
+	"math"
+)
+
+var noteNames = [12]string{"C", "C#", "D", "D#", "E", "F", "F#", "G", "G#", "A", "A#", "B"}
+
+// speaker logs the notes played instead of playing them.
+type speaker struct {
+	b         *Board
+	frequency uint32
+}
+
+func (s *speaker) Tone(frequency uint32) {
+	if frequency == s.frequency {
+		return
+	}
+	s.frequency = frequency
+	if frequency == 0 {
+		s.b.logf("audio: off")
+		return
+	}
+	s.b.logf("audio: %s %dHz", noteName(frequency), frequency)
+}
+
+// noteName returns the name of the note closest to frequency, A4 being
+// 440Hz.
+func noteName(frequency uint32) string {
+	n := int(math.Round(12*math.Log2(float64(frequency)/440))) + 9 + 4*12
+	if n < 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%s%d", noteNames[n%12], n/12)
+}
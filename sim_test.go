@@ -0,0 +1,132 @@
+package ubit
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wencode/ubit/sim"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+// newSimDisplay returns a display on a board that scans a frame on each
+// Step only.
+func newSimDisplay(t *testing.T, out *bytes.Buffer) (*ModDisplay, *sim.Board) {
+	b := sim.NewBoard(out, nil)
+	b.SetRealtime(false)
+	d := NewModDisplay()
+	for i := 0; i < 5; i++ {
+		d.rowPins[i] = b.Rows[i]
+		d.colPins[i] = b.Cols[i]
+	}
+	d.timer = b.Timer()
+	d.On()
+	t.Cleanup(d.Off)
+	return d, b
+}
+
+func golden(t *testing.T, name string, got []byte) {
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := ioutil.WriteFile(path, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("output differs from %s:\n%s", path, got)
+	}
+}
+
+func TestSimScrollText(t *testing.T) {
+	var out bytes.Buffer
+	d, b := newSimDisplay(t, &out)
+	d.ScrollText("Hi")
+	for d.IsAnimating() {
+		b.Render(b.Step())
+		d.animUpdate(d.anim.interval)
+	}
+	b.Render(b.Step())
+	golden(t, "scroll_text", out.Bytes())
+}
+
+func TestSimBrightness(t *testing.T) {
+	var out bytes.Buffer
+	d, b := newSimDisplay(t, &out)
+	for x := int16(0); x < 5; x++ {
+		d.SetBrightness(x, 0, uint8(x*63))
+		d.SetBrightness(x, 4, 255)
+	}
+	d.SetLevel(128)
+	f := b.Step()
+	if f[0] != 0 || f[1] != 32 || f[4] != 127 || f[20] != 128 {
+		t.Errorf("brightness %v", f[:5])
+	}
+	b.Render(f)
+	golden(t, "brightness", out.Bytes())
+}
+
+func TestSimLight(t *testing.T) {
+	var out bytes.Buffer
+	d, b := newSimDisplay(t, &out)
+	d.ReadLightLevel()
+	for i := range d.sense.adc {
+		d.sense.adc[i] = b.SenseADC(senseColumns[i])
+	}
+	b.SetLight(200)
+	for i := 0; i < 100; i++ {
+		if i%20 == 0 {
+			d.ReadLightLevel()
+		}
+		b.Step()
+	}
+	if got := d.ReadLightLevel(); got < 195 || got > 200 {
+		t.Errorf("light level %d, want about 200", got)
+	}
+}
+
+func TestSimButton(t *testing.T) {
+	b := sim.NewBoard(nil, strings.NewReader("b\n"))
+	a, bb := NewModButton(b.ButtonA), NewModButton(b.ButtonB)
+	if a.IsPressed() || bb.IsPressed() {
+		t.Error("button pressed before reading keys")
+	}
+	// reading keys starts with the first button used as an input
+	a.Init()
+	bb.Init()
+	deadline := time.Now().Add(time.Second)
+	for !bb.IsPressed() {
+		if time.Now().After(deadline) {
+			t.Fatal("key b did not press button B")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if a.IsPressed() {
+		t.Error("key b pressed button A")
+	}
+}
+
+func TestSimAudio(t *testing.T) {
+	var out bytes.Buffer
+	b := sim.NewBoard(&out, nil)
+	m := &ModAudio{speaker: b.Speaker()}
+	m.Pitch(440, -1)
+	if !m.IsPlaying() {
+		t.Error("not playing")
+	}
+	m.Pitch(262, 1)
+	if m.IsPlaying() {
+		t.Error("still playing after the duration")
+	}
+	if got, want := out.String(), "audio: A4 440Hz\naudio: C4 262Hz\naudio: off\n"; got != want {
+		t.Errorf("logged %q, want %q", got, want)
+	}
+}
@@ -5,10 +5,15 @@ var (
 	Display *ModDisplay
 	// Audio use to play sounds
 	Audio *ModAudio
+	// ButtonA and ButtonB are the buttons left and right of the display
+	ButtonA *ModButton
+	ButtonB *ModButton
 )
 
 func init() {
 	Display = NewModDisplay()
 	Audio = NewModAudio()
-
+	a, b := newButtonPins()
+	ButtonA = NewModButton(a)
+	ButtonB = NewModButton(b)
 }